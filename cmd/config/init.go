@@ -4,8 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"github.com/qordobacode/cli-v2/pkg"
-	"github.com/qordobacode/cli-v2/pkg/config"
-	"github.com/qordobacode/cli-v2/pkg/general"
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"github.com/spf13/cobra"
@@ -18,7 +17,7 @@ var (
 )
 
 // NewInitCmd function create `init` command
-func NewInitCmd() *cobra.Command {
+func NewInitCmd(application *app.App) *cobra.Command {
 	initCmd := &cobra.Command{
 		Use:         "init",
 		Short:       "Init configuration for CLI from STDIN or file",
@@ -26,10 +25,7 @@ func NewInitCmd() *cobra.Command {
 		Example:     `"qor init", "qor init qordobaconfig.yaml"`,
 		Annotations: map[string]string{"group": "init"},
 	}
-	var local *general.Local
-	configurationService = &config.ConfigurationService{
-		Local: local,
-	}
+	configurationService = application.ConfigurationService
 	return initCmd
 }
 
@@ -2,6 +2,7 @@ package config
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/qordobacode/cli-v2/pkg/mock"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"github.com/stretchr/testify/assert"
@@ -41,7 +42,7 @@ func prepareInit(t *testing.T) {
 }
 
 func TestConfigFileImported(t *testing.T) {
-	initCmd := NewInitCmd()
+	initCmd := NewInitCmd(app.New())
 	prepareInit(t)
 	err := RunInitRoot(initCmd, []string{"file.txt"})
 	assert.Nil(t, err)
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg"
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/mockserver"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/rest"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files of end-to-end tests")
+
+const e2eToken = "e2e-token"
+
+// harness runs commands in temporary working directory against mock server and records a transcript
+// of their output, HTTP calls made and files written
+type harness struct {
+	t          *testing.T
+	dir        string
+	testdata   string
+	server     *httptest.Server
+	config     *types.Config
+	client     pkg.QordobaClient
+	now        func() time.Time
+	mutex      sync.Mutex
+	requests   []string
+	transcript bytes.Buffer
+	restore    []func()
+}
+
+func newHarness(t *testing.T) *harness {
+	dir, err := ioutil.TempDir("", "qor-e2e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &harness{t: t, dir: dir}
+	h.restore = append(h.restore, func() { os.RemoveAll(dir) })
+
+	store, _ := mockserver.NewStore("")
+	mockServer := mockserver.New(store, e2eToken)
+	h.now = func() time.Time {
+		return time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	}
+	mockServer.Now = h.now
+	h.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, request *http.Request) {
+		h.mutex.Lock()
+		h.requests = append(h.requests, request.Method+" "+request.URL.RequestURI())
+		h.mutex.Unlock()
+		mockServer.ServeHTTP(rw, request)
+	}))
+	h.restore = append(h.restore, h.server.Close)
+
+	h.config = &types.Config{BaseURL: h.server.URL}
+	h.config.Qordoba.AccessToken = e2eToken
+	h.config.Qordoba.OrganizationID = mockserver.OrganizationID
+	h.config.Qordoba.WorkspaceID = mockserver.WorkspaceID
+	h.config.Download.Target = "i18n/language_code.<extension>"
+	client, err := rest.NewClient(h.config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Limiter = nil
+	h.client = client
+
+	h.setenv("HOME", filepath.Join(dir, "home"))
+	h.mkdir("work")
+	wd, _ := os.Getwd()
+	h.testdata = filepath.Join(wd, "testdata")
+	if err = os.Chdir(filepath.Join(dir, "work")); err != nil {
+		t.Fatal(err)
+	}
+	h.restore = append(h.restore, func() { os.Chdir(wd) })
+	local := time.Local
+	time.Local = time.UTC
+	h.restore = append(h.restore, func() { time.Local = local })
+	stdout := output.Stdout
+	h.restore = append(h.restore, func() { output.Stdout = stdout })
+	return h
+}
+
+func (h *harness) close() {
+	for i := len(h.restore) - 1; i >= 0; i-- {
+		h.restore[i]()
+	}
+}
+
+func (h *harness) setenv(key, value string) {
+	previous, isSet := os.LookupEnv(key)
+	os.Setenv(key, value)
+	h.restore = append(h.restore, func() {
+		if isSet {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func (h *harness) mkdir(path string) {
+	if err := os.MkdirAll(filepath.Join(h.dir, path), 0755); err != nil {
+		h.t.Fatal(err)
+	}
+}
+
+// write creates file in working directory
+func (h *harness) write(path, content string) {
+	h.mkdir(filepath.Dir(filepath.Join("work", path)))
+	if err := ioutil.WriteFile(filepath.Join(h.dir, "work", path), []byte(content), 0644); err != nil {
+		h.t.Fatal(err)
+	}
+}
+
+// files returns content of files in working directory by their relative paths
+func (h *harness) files() map[string]string {
+	files := make(map[string]string)
+	root := filepath.Join(h.dir, "work")
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relative, _ := filepath.Rel(root, path)
+		files[filepath.ToSlash(relative)] = string(content)
+		return nil
+	})
+	return files
+}
+
+// run executes command and appends its stdout, requests sorted by method and path and changed files to transcript
+func (h *harness) run(args ...string) {
+	before := h.files()
+	h.mutex.Lock()
+	h.requests = nil
+	h.mutex.Unlock()
+	var stdout bytes.Buffer
+	output.Stdout = &stdout
+
+	// every command gets its own copy of config, as flags might change it
+	appConfig := *h.config
+	rootCmd := NewRootCmd(app.WithConfig(&appConfig), app.WithClient(h.client), app.WithClock(h.now, func(time.Duration) {}))
+	rootCmd.SetArgs(args)
+	if err := rootCmd.Execute(); err != nil {
+		h.t.Fatalf("qor %s: %v", strings.Join(args, " "), err)
+	}
+
+	// absolute paths of temporary directory are replaced, so they should be printed without table padding
+	result := strings.ReplaceAll(stdout.String(), filepath.Join(h.dir, "work"), "$WORK")
+	fmt.Fprintf(&h.transcript, "$ qor %s\n%s", strings.Join(args, " "), result)
+	h.mutex.Lock()
+	requests := append([]string{}, h.requests...)
+	h.mutex.Unlock()
+	sort.Strings(requests)
+	fmt.Fprintf(&h.transcript, "--- requests\n")
+	for _, request := range requests {
+		fmt.Fprintln(&h.transcript, request)
+	}
+	after := h.files()
+	paths := make([]string, 0, len(after))
+	for path, content := range after {
+		if previous, ok := before[path]; !ok || previous != content {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(&h.transcript, "--- file %s\n%s\n", path, strings.TrimRight(after[path], "\n"))
+	}
+	fmt.Fprintln(&h.transcript)
+}
+
+// assertGolden compares transcript with `testdata/<name>.golden`. Golden files are rewritten with `-update` flag
+func (h *harness) assertGolden(name string) {
+	path := filepath.Join(h.testdata, name+".golden")
+	if *updateGolden {
+		if err := os.MkdirAll(h.testdata, 0755); err != nil {
+			h.t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, h.transcript.Bytes(), 0644); err != nil {
+			h.t.Fatal(err)
+		}
+	}
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		h.t.Fatalf("golden file not found, run tests with -update flag: %v", err)
+	}
+	assert.Equal(h.t, string(expected), h.transcript.String())
+}
+
+func TestE2E_PushDownload(t *testing.T) {
+	h := newHarness(t)
+	defer h.close()
+	h.write("i18n/en-us.json", `{
+  "title": "Hello",
+  "menu": {"open": "Open file"}
+}
+`)
+	h.run("push", "--files", "i18n/en-us.json", "--output", "csv")
+	h.run("ls")
+	h.run("update-value", "en-us.json", "--key", "/title", "--value", "Bonjour", "-a", "fr-fr")
+	h.run("value-key", "en-us.json", "--key", "/title", "-a", "fr-fr")
+	h.run("download", "-a", "fr-fr", "-c")
+	h.run("status")
+	h.assertGolden("push_download")
+}
+
+func TestE2E_Keys(t *testing.T) {
+	h := newHarness(t)
+	defer h.close()
+	h.write("i18n/en-us.json", `{"title": "Hello"}`)
+	h.run("push", "--files", "i18n/en-us.json", "--output", "csv")
+	h.run("add-key", "en-us.json", "--key", "/menu/close", "--value", "Close")
+	h.run("segments", "export", "en-us.json")
+	h.run("workflow", "move", "en-us.json", "-a", "fr-fr", "--key", "/title", "--step", "Review")
+	h.run("delete-key", "en-us.json", "--key", "/menu/close")
+	h.run("download", "-s", "-c")
+	h.assertGolden("keys")
+}
+
+func TestE2E_ExtractPush(t *testing.T) {
+	h := newHarness(t)
+	defer h.close()
+	h.write("i18n/en-us.json", `{"title": "Hello", "menu": {"open": "Open"}}`)
+	h.write("src/app.js", "t('title')\nt('menu.close')\n")
+	h.run("push", "--files", "i18n/en-us.json", "--output", "csv")
+	h.run("extract", "src", "--push", "--push-file", "en-us.json", "--key-separator", ".", "--output", "csv")
+	h.run("segments", "export", "en-us.json")
+	h.assertGolden("extract_push")
+}
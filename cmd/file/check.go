@@ -0,0 +1,192 @@
+package file
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/resource"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	missingFile = "missing file"
+	invalidFile = "invalid file"
+)
+
+var (
+	checkHeaders = []string{"FILE", "LINE", "AUDIENCE", "KEY", "ISSUE", "DETAILS"}
+)
+
+// checkRow is a single inconsistency of downloaded translation
+type checkRow struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	Audience string `json:"audience"`
+	Key      string `json:"key,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Issue    string `json:"issue"`
+	Details  string `json:"details,omitempty"`
+}
+
+// NewCheckCmd creates `check` command
+func NewCheckCmd(application *app.App) *cobra.Command {
+	checkCmd := &cobra.Command{
+		Annotations: map[string]string{"group": "file"},
+		Use:         "check",
+		Short:       "Check downloaded translations against source files",
+		Long:        "Compares downloaded target files with their source files and reports missing keys, extra keys and placeholder/tag mismatches",
+		Example:     `"qor check", "qor check -a de-de --file-path-pattern language_code --json"`,
+		PreRun:      startLocalServices(application),
+		Run:         func(cmd *cobra.Command, args []string) { checkTranslations(application, cmd, args) },
+	}
+	checkCmd.Flags().StringVarP(&files, "files", "f", "", "Lists the source file paths to check")
+	checkCmd.Flags().BoolVarP(&isFilePath, "file-path", "p", false, "Reads push.sources.folders from config file and check its content")
+	checkCmd.Flags().StringVarP(&downloadAudience, "audience", "a", "", "Option to check only specific (comma-separated) languages. example: `qor check -a en-us,de-de`")
+	checkCmd.Flags().StringVar(&filePathPattern, "file-path-pattern", "", "Language pattern in path of downloaded files. The same as in `download` command")
+	return checkCmd
+}
+
+func checkTranslations(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
+		log.Errorf("error occurred on configuration load")
+		return
+	}
+	if !isFilePathPatternValid() {
+		log.Errorf(`Invalid file-path-pattern "%s"`, filePathPattern)
+		os.Exit(1)
+	}
+	if filePathPattern == "" && application.Config.Download.Target == "" {
+		log.Errorf("Please set the `download.target` field in configuration or use `--file-path-pattern` flag to locate downloaded files")
+		os.Exit(1)
+	}
+	workspace, err := application.WorkspaceService.LoadWorkspace()
+	if err != nil || workspace == nil {
+		os.Exit(1)
+	}
+	if isSourceCode, wasFound := validateWorkspace(workspace); isSourceCode || !wasFound {
+		os.Exit(1)
+	}
+	isFilePathPattern = filePathPattern != ""
+	sources, ok := selectPushFiles(application, args)
+	if !ok {
+		os.Exit(1)
+	}
+	rows := checkSources(application, sources, &workspace.Workspace)
+	printCheckRows(rows)
+	if len(rows) > 0 {
+		os.Exit(1)
+	}
+}
+
+func checkSources(application *app.App, sources []string, workspace *types.Workspace) []*checkRow {
+	rows := make([]*checkRow, 0)
+	forEachTarget(application, sources, workspace, func(sourceFile *resource.File, target string, persona types.Person) {
+		rows = append(rows, checkTarget(application, sourceFile, target, persona.Code)...)
+	})
+	return rows
+}
+
+// forEachTarget calls handler for every supported source file and expected path of its translation
+// into every selected audience
+func forEachTarget(application *app.App, sources []string, workspace *types.Workspace, handler func(sourceFile *resource.File, target string, persona types.Person)) {
+	audiences := selectedAudiences(application)
+	matchFilepathName := buildPatternName(workspace.SourcePersona)
+	for _, source := range sources {
+		if !resource.IsSupported(source) {
+			continue
+		}
+		sourceFile, err := parseResourceFile(application, source)
+		if err != nil {
+			log.Errorf("can't read source file %s: %v", source, err)
+			continue
+		}
+		for _, persona := range workspace.TargetPersonas {
+			if _, ok := audiences[persona.Code]; len(audiences) > 0 && !ok {
+				continue
+			}
+			handler(sourceFile, localTargetPath(application, source, persona, matchFilepathName), persona)
+		}
+	}
+}
+
+func checkTarget(application *app.App, sourceFile *resource.File, target, audience string) []*checkRow {
+	newRow := func(issue, details string) *checkRow {
+		return &checkRow{Source: sourceFile.Path, Target: target, Audience: audience, Issue: issue, Details: details}
+	}
+	if !application.Local.FileExists(target) {
+		return []*checkRow{newRow(missingFile, "")}
+	}
+	targetFile, err := parseResourceFile(application, target)
+	if err != nil {
+		return []*checkRow{newRow(invalidFile, err.Error())}
+	}
+	rows := make([]*checkRow, 0)
+	for _, difference := range resource.Compare(sourceFile, targetFile) {
+		row := newRow(string(difference.Kind), difference.Details)
+		row.Key = difference.Key
+		row.Line = difference.Line
+		if difference.Kind == resource.MissingKey {
+			// line of missing key points to source file
+			row.Line = 0
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func parseResourceFile(application *app.App, path string) (*resource.File, error) {
+	content, err := application.Local.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	return resource.Parse(path, content)
+}
+
+// localTargetPath builds path of downloaded translation of source file the same way as `download` command does
+func localTargetPath(application *app.App, source string, persona types.Person, matchFilepathName []string) string {
+	dir, err := os.Getwd()
+	if err != nil {
+		log.Debugf("error occurred on getting current dir: %v", err)
+	}
+	if len(application.Config.Push.Sources.Folders) > 0 {
+		dir = application.Config.Push.Sources.Folders[0]
+	}
+	relativeFilePath, err := filepath.Rel(dir, source)
+	if err != nil {
+		relativeFilePath = source
+	}
+	replaceIn, replaceMap := buildReplaceInString(persona, filePathPattern)
+	j := &types.File2Download{
+		File: &types.File{
+			Filename: filepath.Base(source),
+			Filepath: filepath.ToSlash(relativeFilePath),
+		},
+		Person:     persona,
+		ReplaceIn:  replaceIn,
+		ReplaceMap: replaceMap,
+	}
+	return application.Config.DownloadPath(application.Local.BuildDirectoryFilePath(j, matchFilepathName, "", isFilePathPattern))
+}
+
+func printCheckRows(rows []*checkRow) {
+	if len(rows) == 0 && output.Current() == output.Table {
+		log.Infof("No issues found")
+		return
+	}
+	data := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		line := ""
+		if row.Line > 0 {
+			line = strconv.Itoa(row.Line)
+		}
+		data = append(data, []string{row.Target, line, row.Audience, row.Key, row.Issue, row.Details})
+	}
+	if err := output.Print(&output.Result{Header: checkHeaders, Rows: data, Value: rows}); err != nil {
+		log.Errorf("error occurred on output: %v", err)
+	}
+	log.Infof("%d issues found", len(rows))
+}
@@ -1,48 +1,40 @@
 package file
 
 import (
-	"github.com/qordobacode/cli-v2/pkg"
-	"github.com/qordobacode/cli-v2/pkg/config"
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/qordobacode/cli-v2/pkg/file"
-	"github.com/qordobacode/cli-v2/pkg/general"
-	"github.com/qordobacode/cli-v2/pkg/rest"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
 	"github.com/qordobacode/cli-v2/pkg/types"
-	"github.com/qordobacode/cli-v2/pkg/workspace"
 	"github.com/spf13/cobra"
-	"os"
+	"sort"
 )
 
-var (
-	appConfig            *types.Config
-	local                *general.Local
-	configurationService = config.ConfigurationService{
-		Local: local,
-	}
-	qordobaClient    pkg.QordobaClient
-	workspaceService pkg.WorkspaceService
-	fileService      pkg.FileService
-)
+var fileResultHeaders = []string{"FILE", "#VERSION", "AUDIENCE", "STATUS", "DETAILS"}
 
-// startLocalServices function build all required for file package services
-func startLocalServices(cmd *cobra.Command, args []string) {
-	var err error
-	appConfig, err = configurationService.LoadConfig()
-	if err != nil {
-		os.Exit(1)
-	}
-	qordobaClient = rest.NewRestClient(appConfig)
-	workspaceService = &workspace.Service{
-		Config:        appConfig,
-		QordobaClient: qordobaClient,
-		Local:         local,
+// startLocalServices returns PreRun function, which initializes application services required by file commands
+func startLocalServices(application *app.App) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		application.MustInit()
+		if service, ok := application.FileService.(*file.Service); ok {
+			service.SkipValidation = isSkipValidation
+		}
 	}
-	fileService = &file.Service{
-		Config:           appConfig,
-		WorkspaceService: workspaceService,
-		Local:            local,
-		QordobaClient:    qordobaClient,
+}
+
+// printFileResults prints pushed or downloaded files sorted by audience and name
+func printFileResults(results []*types.FileResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Audience != results[j].Audience {
+			return results[i].Audience < results[j].Audience
+		}
+		return results[i].File < results[j].File
+	})
+	data := make([][]string, 0, len(results))
+	for _, result := range results {
+		data = append(data, []string{result.File, result.Version, result.Audience, result.Status, result.Error})
 	}
-	local = &general.Local{
-		Config: appConfig,
+	if err := output.Print(&output.Result{Header: fileResultHeaders, Rows: data, Value: results}); err != nil {
+		log.Errorf("error occurred on output: %v", err)
 	}
 }
@@ -0,0 +1,130 @@
+package file
+
+import (
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/resource"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/spf13/cobra"
+	"os"
+	"strconv"
+)
+
+var (
+	isCoverageMarkdown bool
+	coverageHeaders    = []string{"AUDIENCE", "#KEYS", "#TRANSLATED", "#UNTRANSLATED", "#IDENTICAL", "TRANSLATED %"}
+)
+
+// coverageRow is a translation coverage of a single audience across all source files
+type coverageRow struct {
+	Audience string `json:"audience"`
+	resource.Coverage
+	Percent float64               `json:"translated_percent"`
+	Files   []*coverageFileResult `json:"files"`
+}
+
+type coverageFileResult struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Exists bool   `json:"exists"`
+	resource.Coverage
+}
+
+// NewCoverageCmd creates `coverage` command
+func NewCoverageCmd(application *app.App) *cobra.Command {
+	coverageCmd := &cobra.Command{
+		Annotations: map[string]string{"group": "file"},
+		Use:         "coverage",
+		Short:       "Report translation coverage of downloaded files",
+		Long:        "Reads local source files and downloaded files of every audience and reports translated, untranslated and identical to source keys",
+		Example:     `"qor coverage --file-path-pattern language_code", "qor coverage -a de-de,fr-fr --output markdown"`,
+		PreRun:      startLocalServices(application),
+		Run:         func(cmd *cobra.Command, args []string) { printCoverage(application, cmd, args) },
+	}
+	coverageCmd.Flags().StringVarP(&files, "files", "f", "", "Lists the source file paths to report")
+	coverageCmd.Flags().BoolVarP(&isFilePath, "file-path", "p", false, "Reads push.sources.folders from config file and report its content")
+	coverageCmd.Flags().StringVarP(&downloadAudience, "audience", "a", "", "Option to report only specific (comma-separated) languages. example: `qor coverage -a en-us,de-de`")
+	coverageCmd.Flags().StringVar(&filePathPattern, "file-path-pattern", "", "Language pattern in path of downloaded files. The same as in `download` command")
+	coverageCmd.Flags().BoolVar(&isCoverageMarkdown, "markdown", false, "Print output as Markdown table")
+	coverageCmd.Flags().MarkDeprecated("markdown", "use --output markdown instead")
+	return coverageCmd
+}
+
+func printCoverage(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
+		log.Errorf("error occurred on configuration load")
+		return
+	}
+	if !isFilePathPatternValid() {
+		log.Errorf(`Invalid file-path-pattern "%s"`, filePathPattern)
+		os.Exit(1)
+	}
+	if filePathPattern == "" && application.Config.Download.Target == "" {
+		log.Errorf("Please set the `download.target` field in configuration or use `--file-path-pattern` flag to locate downloaded files")
+		os.Exit(1)
+	}
+	workspace, err := application.WorkspaceService.LoadWorkspace()
+	if err != nil || workspace == nil {
+		os.Exit(1)
+	}
+	if isSourceCode, wasFound := validateWorkspace(workspace); isSourceCode || !wasFound {
+		os.Exit(1)
+	}
+	isFilePathPattern = filePathPattern != ""
+	sources, ok := selectPushFiles(application, args)
+	if !ok {
+		os.Exit(1)
+	}
+	rows := coverageRows(application, sources, &workspace.Workspace)
+	if isCoverageMarkdown {
+		output.Selected = string(output.Markdown)
+	}
+	if err := output.Print(&output.Result{Header: coverageHeaders, Rows: coverageTable(rows), Value: rows}); err != nil {
+		log.Errorf("error occurred on output: %v", err)
+	}
+}
+
+func coverageRows(application *app.App, sources []string, workspace *types.Workspace) []*coverageRow {
+	rows := make([]*coverageRow, 0)
+	rowByAudience := make(map[string]*coverageRow)
+	forEachTarget(application, sources, workspace, func(sourceFile *resource.File, target string, persona types.Person) {
+		row, ok := rowByAudience[persona.Code]
+		if !ok {
+			row = &coverageRow{Audience: persona.Code, Files: make([]*coverageFileResult, 0)}
+			rowByAudience[persona.Code] = row
+			rows = append(rows, row)
+		}
+		fileResult := &coverageFileResult{Source: sourceFile.Path, Target: target}
+		var targetFile *resource.File
+		if application.Local.FileExists(target) {
+			var err error
+			targetFile, err = parseResourceFile(application, target)
+			if err != nil {
+				log.Errorf("can't read file %s: %v", target, err)
+			}
+			fileResult.Exists = err == nil
+		}
+		fileResult.Coverage = resource.CompareCoverage(sourceFile, targetFile)
+		row.Files = append(row.Files, fileResult)
+		row.Add(fileResult.Coverage)
+		row.Percent = row.TranslatedPercent()
+	})
+	return rows
+}
+
+func coverageTable(rows []*coverageRow) [][]string {
+	data := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		data = append(data, []string{
+			row.Audience,
+			strconv.Itoa(row.Total),
+			strconv.Itoa(row.Translated),
+			strconv.Itoa(row.Untranslated),
+			strconv.Itoa(row.Identical),
+			fmt.Sprintf("%.1f%%", row.Percent),
+		})
+	}
+	return data
+}
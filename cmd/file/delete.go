@@ -1,6 +1,7 @@
 package file
 
 import (
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/spf13/cobra"
 )
@@ -10,26 +11,26 @@ var (
 )
 
 // NewDeleteFileCmd function build `delete` command for cobra
-func NewDeleteFileCmd() *cobra.Command {
+func NewDeleteFileCmd(application *app.App) *cobra.Command {
 	deleteCmd := &cobra.Command{
 		Annotations: map[string]string{"group": "file"},
 		Use:         "delete",
 		Short:       "Delete files from workspace",
 		Example:     "qor delete file_name.doc --version 1",
-		PreRun:      startLocalServices,
-		Run:         deleteFile,
+		PreRun:      startLocalServices(application),
+		Run:         func(cmd *cobra.Command, args []string) { deleteFile(application, cmd, args) },
 	}
 	deleteCmd.Flags().StringVar(&deleteFileVersion, "version", "", "version of file to delete")
 	return deleteCmd
 }
 
-func deleteFile(cmd *cobra.Command, args []string) {
-	if appConfig == nil {
+func deleteFile(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
 		log.Errorf("error occurred on configuration load")
 		return
 	}
 	if len(args) > 0 {
-		fileService.DeleteFile(args[0], deleteFileVersion)
+		application.FileService.DeleteFile(args[0], deleteFileVersion)
 	} else {
 		log.Infof("No files to delete were specified")
 	}
@@ -15,6 +15,7 @@
 package file
 
 import (
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"github.com/spf13/cobra"
@@ -51,15 +52,15 @@ var (
 )
 
 // NewDownloadCommand command create `download` command
-func NewDownloadCommand() *cobra.Command {
+func NewDownloadCommand(application *app.App) *cobra.Command {
 	downloadCmd := &cobra.Command{
 		Annotations: map[string]string{"group": "file"},
 		Use:         "download",
 		Short:       "Downloads selected files",
 		Long:        "Default file download command will give you two things  A)only the completed files B) will give you all the files (all locals and audiences without source file)",
 		Example:     `qor download -a en-us,de-de`,
-		PreRun:      startLocalServices,
-		Run:         downloadFiles,
+		PreRun:      startLocalServices(application),
+		Run:         func(cmd *cobra.Command, args []string) { downloadFiles(application, cmd, args) },
 	}
 
 	downloadCmd.Flags().BoolVarP(&isDownloadCurrent, "current", "c", false, "Pull the current state of the files")
@@ -79,8 +80,8 @@ func NewDownloadCommand() *cobra.Command {
 	return downloadCmd
 }
 
-func downloadFiles(cmd *cobra.Command, args []string) {
-	if appConfig == nil {
+func downloadFiles(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
 		log.Errorf("error occurred on configuration load")
 		return
 	}
@@ -95,7 +96,7 @@ func downloadFiles(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	workspace, err := workspaceService.LoadWorkspace()
+	workspace, err := application.WorkspaceService.LoadWorkspace()
 	if err != nil || workspace == nil {
 		return
 	}
@@ -105,7 +106,7 @@ func downloadFiles(cmd *cobra.Command, args []string) {
 	}
 	// there might be a chance that in cached workspace we missed new language. Reload workspace from server then.
 	if !wasFound {
-		workspace, err := workspaceService.WorkspaceFromServer()
+		workspace, err := application.WorkspaceService.WorkspaceFromServer()
 		if err != nil || workspace == nil {
 			return
 		}
@@ -114,7 +115,7 @@ func downloadFiles(cmd *cobra.Command, args []string) {
 			return
 		}
 	}
-	if filePathPattern == "" && appConfig.Download.Target == "" && !isDownloadOriginal {
+	if filePathPattern == "" && application.Config.Download.Target == "" && !isDownloadOriginal {
 		log.Infof("Please update configuration and set the `download.target` field. For example `<language_code>-<filename>.<extension>`")
 		os.Exit(1)
 	}
@@ -123,23 +124,25 @@ func downloadFiles(cmd *cobra.Command, args []string) {
 	}
 	isFilePathPattern = filePathPattern != ""
 	matchFilepathName := buildPatternName(workspace.Workspace.SourcePersona)
-	files2Download := files2Download(&workspace.Workspace, filePathPattern)
+	files2Download := files2Download(application, &workspace.Workspace, filePathPattern)
 	jobs := make(chan *types.File2Download, 1000)
-	results := make(chan struct{}, 1000)
+	results := make(chan []*types.FileResult, 1000)
 
 	for i := 0; i < 3; i++ {
-		go worker(jobs, results, matchFilepathName)
+		go worker(application, jobs, results, matchFilepathName)
 	}
 	for _, files2Download := range files2Download {
 		jobs <- files2Download
 	}
 	close(jobs)
+	fileResults := make([]*types.FileResult, 0, len(files2Download))
 	for i := 0; i < len(files2Download); i++ {
-		<-results
+		fileResults = append(fileResults, <-results...)
 	}
 
 	// let all error logs go before final messages
-	time.Sleep(time.Second)
+	application.Sleep(time.Second)
+	printFileResults(fileResults)
 	if isDownloadCurrent {
 		log.Infof("downloaded %v files", ops)
 	} else {
@@ -218,29 +221,33 @@ func buildReplaceInString(person types.Person, filePathPattern string) (string,
 	return replacementMap[filePathPattern], replacementMap
 }
 
-func worker(jobs chan *types.File2Download, results chan struct{}, matchFilepathName []string) {
+func worker(application *app.App, jobs chan *types.File2Download, results chan []*types.FileResult, matchFilepathName []string) {
 	for j := range jobs {
-		handleFile(j, matchFilepathName)
-		results <- struct{}{}
+		results <- handleFile(application, j, matchFilepathName)
 	}
 }
 
-func files2Download(workspace *types.Workspace, filePathTemplate string) []*types.File2Download {
-	audiences := appConfig.Audiences()
-	if downloadAudience != "" {
-		audienceList := strings.Split(downloadAudience, ",")
-		audiences = make(map[string]bool)
-		for _, lang := range audienceList {
-			audiences[lang] = true
-		}
+// selectedAudiences returns languages from `-a` flag or, if it's absent, from config's audience map
+func selectedAudiences(application *app.App) map[string]bool {
+	if downloadAudience == "" {
+		return application.Config.Audiences()
 	}
+	audiences := make(map[string]bool)
+	for _, lang := range strings.Split(downloadAudience, ",") {
+		audiences[lang] = true
+	}
+	return audiences
+}
+
+func files2Download(application *app.App, workspace *types.Workspace, filePathTemplate string) []*types.File2Download {
+	audiences := selectedAudiences(application)
 	files2Download := make([]*types.File2Download, 0)
 	for pi := range workspace.TargetPersonas {
 		persona := workspace.TargetPersonas[pi]
 		if _, ok := audiences[persona.Code]; len(audiences) > 0 && !ok {
 			continue
 		}
-		response, err := fileService.WorkspaceFiles(persona.ID, false)
+		response, err := application.FileService.WorkspaceFiles(persona.ID, false)
 		if err != nil {
 			continue
 		}
@@ -261,25 +268,50 @@ func files2Download(workspace *types.Workspace, filePathTemplate string) []*type
 	return files2Download
 }
 
-func handleFile(j *types.File2Download, matchFilepathName []string) {
+// handleFile downloads file in all requested variants and returns result of every download
+func handleFile(application *app.App, j *types.File2Download, matchFilepathName []string) []*types.FileResult {
 	if !j.File.Completed && !isDownloadCurrent && !isDownloadOriginal {
 		// isDownloadCurrent - skip files with version
 		log.Infof("file %s is not completed. Use flag '-c' or '--current' to download even not completed files", j.File.Filename)
-		return
+		return []*types.FileResult{skippedDownload(j, j.File.Filename, "file is not completed")}
 	}
 	if j.File.ErrorID != 0 || !j.File.Enabled {
 		handleInvalidFile(j.File)
-		return
+		reason := "file is disabled"
+		if j.File.ErrorID != 0 {
+			reason = "file has error"
+		}
+		return []*types.FileResult{skippedDownload(j, j.File.Filename, reason)}
 	}
-	if isDownloadSource && !(filePathPattern == "" && appConfig.Download.Target == "") {
-		downloadSourceFile(j)
+	results := make([]*types.FileResult, 0, 2)
+	if isDownloadSource && !(filePathPattern == "" && application.Config.Download.Target == "") {
+		results = append(results, downloadSourceFile(application, j))
 	}
 	if isDownloadOriginal {
-		downloadOriginalFile(j, matchFilepathName)
+		results = append(results, downloadOriginalFile(application, j, matchFilepathName))
 	}
 	if !isDownloadOriginal && !isDownloadSource {
-		downloadFile(j, matchFilepathName)
+		results = append(results, downloadFile(application, j, matchFilepathName))
 	}
+	return results
+}
+
+func skippedDownload(j *types.File2Download, fileName, reason string) *types.FileResult {
+	return &types.FileResult{
+		File:     fileName,
+		Version:  j.File.Version,
+		Audience: j.Person.Code,
+		Status:   types.FileSkipped,
+		Error:    reason,
+	}
+}
+
+func downloadResult(j *types.File2Download, fileName string, err error) *types.FileResult {
+	if err != nil {
+		return &types.FileResult{File: fileName, Version: j.File.Version, Audience: j.Person.Code, Status: types.FileFailed, Error: err.Error()}
+	}
+	atomic.AddUint64(&ops, 1)
+	return &types.FileResult{File: fileName, Version: j.File.Version, Audience: j.Person.Code, Status: types.FileDownloaded}
 }
 
 func handleInvalidFile(file *types.File) {
@@ -301,41 +333,41 @@ func handleInvalidFile(file *types.File) {
 	}
 }
 
-func downloadFile(j *types.File2Download, matchFilepathName []string) {
+func downloadFile(application *app.App, j *types.File2Download, matchFilepathName []string) *types.FileResult {
 	dir := filepath.Dir(j.File.Filepath)
-	if appConfig.Download.Target != "" && dir != "" && dir != "." {
+	if application.Config.Download.Target != "" && dir != "" && dir != "." {
 		log.Infof("[TARGET] file '%s' has file path. File path is not supported with config`download.target`. Skip.", j.File.Filepath)
-		return
+		return skippedDownload(j, j.File.Filepath, "file path is not supported with download.target")
 	}
-	fileName := local.BuildDirectoryFilePath(j, matchFilepathName, "", isFilePathPattern)
-	if !isDownloadSkip || !local.FileExists(fileName) {
-		fileService.DownloadFile(j.Person, fileName, j.File)
-		atomic.AddUint64(&ops, 1)
+	fileName := application.Local.BuildDirectoryFilePath(j, matchFilepathName, "", isFilePathPattern)
+	if isDownloadSkip && application.Local.FileExists(fileName) {
+		return skippedDownload(j, fileName, "file exists")
 	}
+	return downloadResult(j, fileName, application.FileService.DownloadFile(j.Person, fileName, j.File))
 }
 
-func downloadSourceFile(j *types.File2Download) {
+func downloadSourceFile(application *app.App, j *types.File2Download) *types.FileResult {
 	dir := filepath.Dir(j.File.Filepath)
-	if appConfig.Download.Target != "" && dir != "" && dir != "." {
+	if application.Config.Download.Target != "" && dir != "" && dir != "." {
 		log.Infof("[SOURCE] file '%s' has file path. File path is not supported with config `download.target`. Skip.", j.File.Filepath)
-		return
+		return skippedDownload(j, j.File.Filepath, "file path is not supported with download.target")
 	}
-	fileName := local.BuildDirectoryFilePath(j, []string{}, "", true)
-	if !isDownloadSkip || !local.FileExists(fileName) {
-		fileService.DownloadSourceFile(fileName, j.File, true)
-		atomic.AddUint64(&ops, 1)
+	fileName := application.Local.BuildDirectoryFilePath(j, []string{}, "", true)
+	if isDownloadSkip && application.Local.FileExists(fileName) {
+		return skippedDownload(j, fileName, "file exists")
 	}
+	return downloadResult(j, fileName, application.FileService.DownloadSourceFile(fileName, j.File, true))
 }
 
-func downloadOriginalFile(j *types.File2Download, matchFilepathName []string) {
+func downloadOriginalFile(application *app.App, j *types.File2Download, matchFilepathName []string) *types.FileResult {
 	suffix := ""
 	if isDownloadSource {
 		// note if the customer using -s and -o in the same command rename the file original to filename-original.xxx
 		suffix = original
 	}
-	fileName := local.BuildDirectoryFilePath(j, []string{}, suffix, true)
-	if !isDownloadSkip || !local.FileExists(fileName) {
-		fileService.DownloadSourceFile(fileName, j.File, false)
-		atomic.AddUint64(&ops, 1)
+	fileName := application.Local.BuildDirectoryFilePath(j, []string{}, suffix, true)
+	if isDownloadSkip && application.Local.FileExists(fileName) {
+		return skippedDownload(j, fileName, "file exists")
 	}
+	return downloadResult(j, fileName, application.FileService.DownloadSourceFile(fileName, j.File, false))
 }
@@ -0,0 +1,140 @@
+package file
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/pseudo"
+	"github.com/qordobacode/cli-v2/pkg/resource"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+)
+
+const (
+	pseudoLanguage    = "en-xa"
+	pseudoRTLLanguage = "ar-xb"
+)
+
+var (
+	pseudoLanguageCode string
+	pseudoOptions      = pseudo.DefaultOptions()
+	isPseudoNoAccents  bool
+	isPseudoNoBrackets bool
+	// pseudoNames are used for `language_name` variants of file path pattern
+	pseudoNames = map[string]string{
+		pseudoLanguage:    "English - Pseudo",
+		pseudoRTLLanguage: "Arabic - Pseudo",
+	}
+)
+
+// NewPseudoCmd creates `pseudo` command
+func NewPseudoCmd(application *app.App) *cobra.Command {
+	pseudoCmd := &cobra.Command{
+		Annotations: map[string]string{"group": "file"},
+		Use:         "pseudo",
+		Short:       "Generate pseudo-localized files from source files",
+		Long:        "Generates pseudo-localized versions of source files (accented characters, length expansion, bracket markers, optional RTL mirroring) with the same layout as `download` command",
+		Example:     `"qor pseudo --file-path-pattern language_code", "qor pseudo --rtl --expansion 0.5"`,
+		PreRun:      startLocalServices(application),
+		Run:         func(cmd *cobra.Command, args []string) { generatePseudoFiles(application, cmd, args) },
+	}
+	pseudoCmd.Flags().StringVarP(&files, "files", "f", "", "Lists the source file paths to pseudo-localize")
+	pseudoCmd.Flags().BoolVarP(&isFilePath, "file-path", "p", false, "Reads push.sources.folders from config file and pseudo-localize its content")
+	pseudoCmd.Flags().StringVar(&filePathPattern, "file-path-pattern", "", "Language pattern in path of generated files. The same as in `download` command")
+	pseudoCmd.Flags().StringVarP(&pseudoLanguageCode, "language", "l", "", "Code of pseudo-language used in file paths (default `en-xa`, `ar-xb` with --rtl)")
+	pseudoCmd.Flags().BoolVar(&pseudoOptions.RTL, "rtl", false, "Mirror text with right-to-left override characters")
+	pseudoCmd.Flags().Float64Var(&pseudoOptions.Expansion, "expansion", pseudoOptions.Expansion, "Ratio of text length added as padding")
+	pseudoCmd.Flags().BoolVar(&isPseudoNoAccents, "no-accents", false, "Keep latin letters without accents")
+	pseudoCmd.Flags().BoolVar(&isPseudoNoBrackets, "no-brackets", false, "Don't wrap values with `[` and `]`")
+	return pseudoCmd
+}
+
+func generatePseudoFiles(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
+		log.Errorf("error occurred on configuration load")
+		return
+	}
+	if !isFilePathPatternValid() {
+		log.Errorf(`Invalid file-path-pattern "%s"`, filePathPattern)
+		os.Exit(1)
+	}
+	if filePathPattern == "" && application.Config.Download.Target == "" {
+		log.Errorf("Please set the `download.target` field in configuration or use `--file-path-pattern` flag to place generated files")
+		os.Exit(1)
+	}
+	workspace, err := application.WorkspaceService.LoadWorkspace()
+	if err != nil || workspace == nil {
+		os.Exit(1)
+	}
+	isFilePathPattern = filePathPattern != ""
+	pseudoOptions.Accents = !isPseudoNoAccents
+	pseudoOptions.Brackets = !isPseudoNoBrackets
+	sources, ok := selectPushFiles(application, args)
+	if !ok {
+		os.Exit(1)
+	}
+	persona := pseudoPersona()
+	matchFilepathName := buildPatternName(workspace.Workspace.SourcePersona)
+	generated := 0
+	for _, source := range sources {
+		if !resource.IsSupported(source) {
+			continue
+		}
+		target := localTargetPath(application, source, persona, matchFilepathName)
+		if isSamePath(source, target) {
+			log.Errorf("pseudo-localized file path is the same as source file %s. Skip it", source)
+			continue
+		}
+		if generatePseudoFile(application, source, target, persona.Code) {
+			generated++
+		}
+	}
+	log.Infof("generated %v pseudo-localized files for language %s", generated, persona.Code)
+}
+
+func isSamePath(source, target string) bool {
+	sourcePath, err := filepath.Abs(source)
+	if err != nil {
+		return false
+	}
+	targetPath, err := filepath.Abs(target)
+	return err == nil && sourcePath == targetPath
+}
+
+func pseudoPersona() types.Person {
+	code := pseudoLanguageCode
+	if code == "" {
+		code = pseudoLanguage
+		if pseudoOptions.RTL {
+			code = pseudoRTLLanguage
+		}
+	}
+	name, ok := pseudoNames[code]
+	if !ok {
+		name = "Pseudo - " + code
+	}
+	return types.Person{Code: code, Name: name}
+}
+
+func generatePseudoFile(application *app.App, source, target, locale string) bool {
+	sourceFile, err := parseResourceFile(application, source)
+	if err != nil {
+		log.Errorf("can't pseudo-localize file %s: %v", source, err)
+		return false
+	}
+	content, err := sourceFile.Transform(func(raw string) string {
+		return pseudo.Localize(raw, pseudoOptions)
+	})
+	if err == nil && sourceFile.Format == resource.YAML {
+		// Rails-like files are loaded by their root locale key
+		content, err = resource.ReplaceLocaleRoot(content, locale)
+	}
+	if err != nil {
+		log.Errorf("can't pseudo-localize file %s: %v", source, err)
+		return false
+	}
+	application.Local.Write(target, content)
+	log.Infof("file %s was generated", target)
+	return true
+}
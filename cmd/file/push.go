@@ -1,6 +1,7 @@
 package file
 
 import (
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/qordobacode/cli-v2/pkg/file"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/spf13/cobra"
@@ -9,71 +10,83 @@ import (
 )
 
 var (
-	pushVersion string
-	files       string
-	isFilePath  bool
+	pushVersion      string
+	files            string
+	isFilePath       bool
+	isSkipValidation bool
 )
 
 // NewPushCmd creates `push` command
-func NewPushCmd() *cobra.Command {
+func NewPushCmd(application *app.App) *cobra.Command {
 	// pushCmd represents the push command
 	pushCmd := &cobra.Command{
 		Annotations: map[string]string{"group": "file"},
 		Use:         "push",
 		Short:       "Push files or folders",
 		Example:     `qor push --files testing.json --version 1.1 --verbose`,
-		PreRun:      startLocalServices,
-		Run:         pushCommand,
+		PreRun:      startLocalServices(application),
+		Run:         func(cmd *cobra.Command, args []string) { pushCommand(application, cmd, args) },
 	}
 	pushCmd.Flags().StringVarP(&pushVersion, "version", "v", "", "Set version to pushed file")
 	pushCmd.Flags().StringVarP(&files, "files", "f", "", "Lists the file paths to upload")
 	pushCmd.Flags().BoolVarP(&isFilePath, "file-path", "p", false, "Reads push.sources.folders from config file and push its content to server")
+	pushCmd.Flags().BoolVar(&isSkipValidation, "skip-validation", false, "Push files without local validation of their content")
 	return pushCmd
 }
 
-func pushCommand(cmd *cobra.Command, args []string) {
-	if appConfig == nil {
+func pushCommand(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
 		log.Errorf("error occurred on configuration load")
 		return
 	}
-	if isFilePath && appConfig.Download.Target != "" {
+	if isFilePath && application.Config.Download.Target != "" {
 		log.Errorf("Please remove `download.target` from your configuration file; it is not supported with file paths.")
 		return
 	}
+	fileList, ok := selectPushFiles(application, args)
+	if !ok {
+		return
+	}
+	printFileResults(application.FileService.PushFiles(fileList, pushVersion, isFilePath))
+	if file.TotalSkipped > 0 {
+		log.Infof(`%v files were skipped as their extension did not match one of: %s`, file.TotalSkipped, file.MimeTypes)
+	}
+}
 
+// selectPushFiles collects files to push from command's arguments, `--files` flag or `push.sources` config
+func selectPushFiles(application *app.App, args []string) ([]string, bool) {
 	if !isFilePath && files == "" && len(args) == 0 {
-		pushSources := appConfig.Push.Sources
+		pushSources := application.Config.Push.Sources
 
 		log.Infof("no '--files' or '--file-path' params in command. 'push.source' param from config is used\n  File: %v\n  Folders: %v", pushSources.Files, pushSources.Folders)
-		fileService.PushFiles(pushSources.Files, pushVersion, false)
+		fileList := make([]string, 0, len(pushSources.Files))
+		fileList = append(fileList, pushSources.Files...)
 		for _, folder := range pushSources.Folders {
 			if !filepath.IsAbs(folder) {
 				log.Errorf("Please provide an absolute path for config parameter `push.sources.folders`")
 				os.Exit(1)
 			}
-			fileService.PushFolder(folder, pushVersion, isFilePath)
+			fileList = append(fileList, application.Local.FilesInFolder(folder, false)...)
 		}
-		if file.TotalSkipped > 0 {
-			log.Infof(`%v files were skipped as their extension did not match one of: %s`, file.TotalSkipped, file.MimeTypes)
-		}
-		return
+		return fileList, true
 	}
 	if files != "" || len(args) != 0 {
-		fileList := filepath.SplitList(files)
+		pathList := filepath.SplitList(files)
 		for _, arg := range args {
 			argFiles := filepath.SplitList(arg)
-			fileList = append(fileList, argFiles...)
+			pathList = append(pathList, argFiles...)
 		}
-		for _, file := range fileList {
-			fileService.PushFolder(file, pushVersion, isFilePath)
-		}
-	} else if isFilePath {
-		if len(appConfig.Push.Sources.Folders) > 0 {
-			log.Infof("Files being recursively pushed from path provided in configuration at `push.sources.folders`: \"%s\"",
-				appConfig.Push.Sources.Folders[0])
-			fileService.PushFolder(appConfig.Push.Sources.Folders[0], pushVersion, isFilePath)
-		} else {
-			log.Errorf("--file-path variants uses push.sources.folders from config and push it on server")
+		fileList := make([]string, 0, len(pathList))
+		for _, path := range pathList {
+			fileList = append(fileList, application.Local.FilesInFolder(path, isFilePath)...)
 		}
+		return fileList, true
+	}
+	if len(application.Config.Push.Sources.Folders) > 0 {
+		log.Infof("Files being recursively pushed from path provided in configuration at `push.sources.folders`: \"%s\"",
+			application.Config.Push.Sources.Folders[0])
+		return application.Local.FilesInFolder(application.Config.Push.Sources.Folders[0], true), true
 	}
+	log.Errorf("--file-path variants uses push.sources.folders from config and push it on server")
+	return nil, false
 }
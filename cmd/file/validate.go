@@ -0,0 +1,55 @@
+package file
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/resource"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var (
+	isValidateStrict bool
+)
+
+// NewValidateCmd creates `validate` command
+func NewValidateCmd(application *app.App) *cobra.Command {
+	validateCmd := &cobra.Command{
+		Annotations: map[string]string{"group": "file"},
+		Use:         "validate",
+		Short:       "Validate source files selected for push",
+		Long:        "Checks syntax, duplicate keys, empty values, ICU/printf placeholders and encoding of files, which would be pushed with the same parameters",
+		Example:     `"qor validate", "qor validate --files i18n/en.json --strict"`,
+		PreRun:      startLocalServices(application),
+		Run:         func(cmd *cobra.Command, args []string) { validateFiles(application, cmd, args) },
+	}
+	validateCmd.Flags().StringVarP(&files, "files", "f", "", "Lists the file paths to validate")
+	validateCmd.Flags().BoolVarP(&isFilePath, "file-path", "p", false, "Reads push.sources.folders from config file and validate its content")
+	validateCmd.Flags().BoolVar(&isValidateStrict, "strict", false, "Treat warnings as errors")
+	return validateCmd
+}
+
+func validateFiles(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
+		log.Errorf("error occurred on configuration load")
+		return
+	}
+	fileList, ok := selectPushFiles(application, args)
+	if !ok {
+		return
+	}
+	issues := application.FileService.ValidateFiles(fileList)
+	errorsNum := 0
+	for _, issue := range issues {
+		if issue.Severity == resource.Error {
+			errorsNum++
+			log.Errorf("%v", issue)
+		} else {
+			log.Infof("%v", issue)
+		}
+	}
+	log.Infof("%d errors, %d warnings", errorsNum, len(issues)-errorsNum)
+	if resource.HasErrors(issues, isValidateStrict) {
+		os.Exit(1)
+	}
+}
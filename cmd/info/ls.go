@@ -1,9 +1,10 @@
 package info
 
 import (
-	"encoding/json"
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/qordobacode/cli-v2/pkg/general/date"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"github.com/spf13/cobra"
 	"sort"
@@ -19,32 +20,30 @@ const (
 
 // lsCmd represents the ls command
 var (
-	IsJSON    bool
 	lsHeaders = []string{"ID", "NAME", "version", "tag", "UPDATED_ON", "STATUS"}
 )
 
 // NewLsCommand function create `ls` command
-func NewLsCommand() *cobra.Command {
+func NewLsCommand(application *app.App) *cobra.Command {
 	lsCmd := &cobra.Command{
 		Annotations: map[string]string{"group": "info"},
 		Use:         "ls",
 		Short:       "Lists files (show 50 only)",
-		Example:     `"qor ls", "qor ls --json"`,
-		PreRun:      startLocalServices,
-		Run:         printLs,
+		Example:     `"qor ls", "qor ls --json", "qor ls --output yaml"`,
+		PreRun:      startLocalServices(application),
+		Run:         func(cmd *cobra.Command, args []string) { printLs(application, cmd, args) },
 	}
-	lsCmd.PersistentFlags().BoolVar(&IsJSON, "json", false, "Print output in JSON format")
 	return lsCmd
 }
 
-func printLs(cmd *cobra.Command, args []string) {
-	workspace, err := workspaceService.LoadWorkspace()
+func printLs(application *app.App, cmd *cobra.Command, args []string) {
+	workspace, err := application.WorkspaceService.LoadWorkspace()
 	if err != nil {
 		return
 	}
 	data := make([]*responseRow, 0)
 	for _, targetPersona := range workspace.Workspace.TargetPersonas {
-		result := handlePersonResult(&targetPersona)
+		result := handlePersonResult(application, &targetPersona)
 		data = append(data, result...)
 		if len(data) > lineLimit {
 			data = data[:lineLimit]
@@ -60,26 +59,19 @@ func printLs(cmd *cobra.Command, args []string) {
 }
 
 func printFile2Stdin(response []*responseRow) {
-	if !IsJSON {
-		data := formatResponse2Array(response)
-		local.RenderTable2Stdin(lsHeaders, data)
-	} else {
-		bytes, err := json.MarshalIndent(response, "", "  ")
-		if err != nil {
-			log.Errorf("error occurred on marshalling with JSON: %v", err)
-			return
-		}
-		log.Infof("%v", string(bytes))
+	result := &output.Result{Header: lsHeaders, Rows: formatResponse2Array(response), Value: response}
+	if err := output.Print(result); err != nil {
+		log.Errorf("error occurred on output: %v", err)
 	}
 }
 
-func handlePersonResult(persona *types.Person) []*responseRow {
-	files, e := fileService.WorkspaceFilesWithLimit(persona.ID, false, lineLimit)
+func handlePersonResult(application *app.App, persona *types.Person) []*responseRow {
+	files, e := application.FileService.WorkspaceFilesWithLimit(persona.ID, false, lineLimit)
 	data := make([]*responseRow, 0)
 	if e != nil {
 		return data
 	}
-	audiences := appConfig.Audiences()
+	audiences := application.Config.Audiences()
 	for _, file := range files.Files {
 		if _, ok := audiences[persona.Code]; len(audiences) > 0 && !ok {
 			continue
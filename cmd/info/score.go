@@ -1,36 +1,242 @@
 package info
 
 import (
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/score"
+	"github.com/qordobacode/cli-v2/pkg/types"
 	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 )
 
 var (
-	scoreFileName    string
+	scoreFileNames   []string
 	scoreFileVersion string
+	scoreTag         string
+	isScoreAll       bool
+	scoreMin         float64
+	isScoreRecord    bool
+	scoreHistory     string
+	scoreHeaders     = []string{"FILE NAME", "#VERSION", "SCORE"}
 )
 
+const defaultScoreHistory = ".qordoba-score-history.jsonl"
+
+// fileScore is a score of a single file
+type fileScore struct {
+	SnapshotTime int64             `json:"snapshot_time"`
+	File         string            `json:"file"`
+	Version      string            `json:"version"`
+	Score        float64           `json:"score"`
+	Breakdown    []types.Breakdown `json:"breakdown"`
+}
+
 // NewScoreCommand creates `score` command
-func NewScoreCommand() *cobra.Command {
+func NewScoreCommand(application *app.App) *cobra.Command {
 	scoreCommand := &cobra.Command{
 		Annotations: map[string]string{"group": "info"},
-		Use:         "score",
+		Use:         "score [files or patterns]",
 		Short:       "Score per file",
-
-		PreRun: startLocalServices,
-		Run:    scoreFile,
+		Long:        "Prints content score of files with breakdown by category. Files are selected by names or glob patterns, by tag or all files of workspace",
+		Example: `qor score en.json
+qor score "*.json" --version v2 --min-score 8
+qor score --all --json
+qor score --tag release --min-score 7.5
+qor score --all --record`,
+		PreRun: startLocalServices(application),
+		Run:    func(cmd *cobra.Command, args []string) { scoreFiles(application, cmd, args) },
 	}
-	scoreCommand.Flags().StringVarP(&scoreFileName, "files", "f", "", "File to score")
-	scoreCommand.Flags().StringVarP(&scoreFileVersion, "version", "v", "", "Version of file to score")
+	scoreCommand.Flags().StringSliceVarP(&scoreFileNames, "files", "f", nil, "Files or glob patterns to score")
+	scoreCommand.Flags().StringVarP(&scoreFileVersion, "version", "v", "", "Version of files to score")
+	scoreCommand.Flags().StringVar(&scoreTag, "tag", "", "Score files with tag")
+	scoreCommand.Flags().BoolVar(&isScoreAll, "all", false, "Score all files of workspace")
+	scoreCommand.Flags().Float64Var(&scoreMin, "min-score", 0, "Exit with non-zero code if score of any file is below the threshold")
+	scoreCommand.Flags().BoolVar(&isScoreRecord, "record", false, "Append scores to history file")
+	scoreCommand.PersistentFlags().StringVar(&scoreHistory, "history", defaultScoreHistory, "Score history file")
+	scoreCommand.AddCommand(newScoreDiffCommand())
 	return scoreCommand
 }
 
-func scoreFile(cmd *cobra.Command, args []string) {
-	if scoreFileName == "" && len(args) > 0 {
-		scoreFileName = args[0]
+func scoreFiles(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
+		log.Errorf("error occurred on configuration load")
+		return
+	}
+	patterns := append(append([]string{}, scoreFileNames...), args...)
+	if len(patterns) == 0 && scoreTag == "" && !isScoreAll {
+		log.Errorf("Please provide files to score, `--tag` or `--all` flag")
+		os.Exit(1)
+	}
+	workspace, err := application.WorkspaceService.LoadWorkspace()
+	if err != nil || workspace == nil {
+		return
+	}
+	if len(workspace.Workspace.TargetPersonas) == 0 {
+		log.Errorf("workspace has no target personas")
+		return
+	}
+	persona := workspace.Workspace.TargetPersonas[0]
+	// progress status is requested, as it contains word counts used for scoring
+	response, err := application.FileService.WorkspaceFiles(persona.ID, true)
+	if err != nil {
+		log.Errorf("error occurred on files load: %v", err)
+		return
+	}
+	files := selectScoreFiles(response.Files, patterns)
+	if len(files) == 0 {
+		log.Errorf("No files matched")
+		os.Exit(1)
+	}
+	scores, unscored := scoreSelectedFiles(application, files, persona.ID)
+	printScores(scores)
+	if isScoreRecord && len(scores) > 0 {
+		recordScores(application, scores)
+	}
+	isFailed := len(unscored) > 0
+	if isFailed {
+		log.Errorf("%d of %d files were not scored: %s", len(unscored), len(files), strings.Join(unscored, ", "))
+	}
+	if failed := belowMinScore(scores); len(failed) > 0 {
+		log.Errorf("Score of %s is below %v", strings.Join(failed, ", "), scoreMin)
+		isFailed = true
+	}
+	if isFailed {
+		os.Exit(1)
+	}
+}
+
+// scoreSelectedFiles scores every file. Files failed to score are logged and returned separately, so results of others aren't lost
+func scoreSelectedFiles(application *app.App, files []types.File, personaID int) ([]*fileScore, []string) {
+	scores := make([]*fileScore, 0, len(files))
+	unscored := make([]string, 0)
+	for i := range files {
+		scoreResponse, err := application.FileService.ScoreFile(&files[i], personaID)
+		if err != nil {
+			log.Errorf("can't score %s: %v", files[i].Filename, err)
+			unscored = append(unscored, strings.TrimSpace(files[i].Filename+" "+files[i].Version))
+			continue
+		}
+		scores = append(scores, &fileScore{
+			SnapshotTime: scoreResponse.SnapshotTime,
+			File:         files[i].Filename,
+			Version:      files[i].Version,
+			Score:        scoreResponse.DocumentScore,
+			Breakdown:    scoreResponse.Breakdown,
+		})
+	}
+	return scores, unscored
+}
+
+// selectScoreFiles returns files matching names or glob patterns, `--tag` and `--version` flags
+func selectScoreFiles(files []types.File, patterns []string) []types.File {
+	selected := make([]types.File, 0)
+	for _, file := range files {
+		if file.Deleted || (scoreFileVersion != "" && file.Version != scoreFileVersion) {
+			continue
+		}
+		if scoreTag != "" && !hasTag(&file, scoreTag) {
+			continue
+		}
+		if len(patterns) > 0 && !matchesAny(file.Filename, patterns) {
+			continue
+		}
+		selected = append(selected, file)
+	}
+	return selected
+}
+
+func hasTag(file *types.File, tag string) bool {
+	for _, fileTag := range file.Tags {
+		if fileTag.Name == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(fileName string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, err := filepath.Match(pattern, fileName); matched || (err != nil && pattern == fileName) {
+			return true
+		}
+	}
+	return false
+}
+
+func belowMinScore(scores []*fileScore) []string {
+	failed := make([]string, 0)
+	for _, score := range scores {
+		if score.Score < scoreMin {
+			failed = append(failed, strings.TrimSpace(score.File+" "+score.Version))
+		}
+	}
+	return failed
+}
+
+func recordScores(application *app.App, scores []*fileScore) {
+	records := make([]score.Record, 0, len(scores))
+	for _, fileScore := range scores {
+		snapshotTime := fileScore.SnapshotTime
+		if snapshotTime == 0 {
+			snapshotTime = application.Now().UnixNano() / int64(time.Millisecond)
+		}
+		records = append(records, score.Record{
+			SnapshotTime: snapshotTime,
+			File:         fileScore.File,
+			Version:      fileScore.Version,
+			Score:        fileScore.Score,
+			Breakdown:    fileScore.Breakdown,
+		})
+	}
+	if err := score.Append(scoreHistory, records); err != nil {
+		log.Errorf("can't record scores into %s: %v", scoreHistory, err)
+		os.Exit(1)
+	}
+	log.Infof("%d scores were recorded into %s", len(records), scoreHistory)
+}
+
+func printScores(scores []*fileScore) {
+	header, data := scoreTable(scores)
+	if err := output.Print(&output.Result{Header: header, Rows: data, Value: scores}); err != nil {
+		log.Errorf("error occurred on output: %v", err)
+	}
+}
+
+// scoreTable returns a row for every file with a column for every enabled breakdown category
+func scoreTable(scores []*fileScore) ([]string, [][]string) {
+	categorySet := make(map[string]bool)
+	for _, score := range scores {
+		for _, breakdown := range score.Breakdown {
+			if breakdown.Enabled {
+				categorySet[breakdown.Category] = true
+			}
+		}
+	}
+	categories := make([]string, 0, len(categorySet))
+	for category := range categorySet {
+		categories = append(categories, category)
 	}
-	score := fileService.FileScore(scoreFileName, scoreFileVersion)
-	if score != nil {
-		log.Infof("%v", score.DocumentScore)
+	sort.Strings(categories)
+	header := append(append([]string{}, scoreHeaders...), categories...)
+	data := make([][]string, 0, len(scores))
+	for _, score := range scores {
+		row := []string{score.File, score.Version, fmt.Sprintf("%.2f", score.Score)}
+		for _, category := range categories {
+			cell := ""
+			for _, breakdown := range score.Breakdown {
+				if breakdown.Category == category && breakdown.Enabled {
+					cell = fmt.Sprintf("%.2f (%d issues)", breakdown.Score, breakdown.IssueCount)
+				}
+			}
+			row = append(row, cell)
+		}
+		data = append(data, row)
 	}
+	return header, data
 }
@@ -0,0 +1,135 @@
+package info
+
+import (
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/score"
+	"github.com/spf13/cobra"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	diffSnapshot         int64
+	diffRef              string
+	diffFailOnRegression bool
+	scoreDiffHeaders     = []string{"FILE NAME", "#VERSION", "CATEGORY", "PREVIOUS", "CURRENT", "ISSUES", "REGRESSION"}
+)
+
+func newScoreDiffCommand() *cobra.Command {
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare latest recorded scores with previous ones",
+		Long: "Compares the latest score and category breakdown of every file in history file with the previous record, " +
+			"a record of snapshot time or the history file at git ref",
+		Example: `qor score diff
+qor score diff --snapshot 1561500000000
+qor score diff --ref origin/master --fail-on-regression`,
+		Args: cobra.NoArgs,
+		Run:  diffScores,
+	}
+	diffCmd.Flags().Int64Var(&diffSnapshot, "snapshot", 0, "compare with records not later than snapshot time")
+	diffCmd.Flags().StringVar(&diffRef, "ref", "", "compare with the latest records of history file at git ref")
+	diffCmd.Flags().BoolVar(&diffFailOnRegression, "fail-on-regression", false, "exit with non-zero code if any score regressed")
+	return diffCmd
+}
+
+func diffScores(cmd *cobra.Command, args []string) {
+	records, err := score.Read(scoreHistory)
+	if err != nil {
+		log.Errorf("can't read score history: %v", err)
+		os.Exit(1)
+	}
+	previous, err := previousScores(records)
+	if err != nil {
+		log.Errorf("%v", err)
+		os.Exit(1)
+	}
+	changes := score.Diff(previous, score.Latest(records))
+	printScoreChanges(changes)
+	if regressions := countRegressions(changes); diffFailOnRegression && regressions > 0 {
+		log.Errorf("%d scores regressed", regressions)
+		os.Exit(1)
+	}
+}
+
+func previousScores(records []score.Record) ([]score.Record, error) {
+	switch {
+	case diffRef != "":
+		path, err := repositoryPath(scoreHistory)
+		if err != nil {
+			return nil, err
+		}
+		content, err := exec.Command("git", "show", diffRef+":"+path).Output()
+		if err != nil {
+			return nil, fmt.Errorf("can't read %s at %s: %v", scoreHistory, diffRef, err)
+		}
+		refRecords, err := score.Parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse %s at %s: %v", scoreHistory, diffRef, err)
+		}
+		return score.Latest(refRecords), nil
+	case diffSnapshot != 0:
+		return score.Until(records, diffSnapshot), nil
+	}
+	return score.Previous(records), nil
+}
+
+// repositoryPath returns path relative to the top-level directory of git repository, as it's expected by `git show`
+func repositoryPath(path string) (string, error) {
+	topLevel, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", fmt.Errorf("can't find git repository: %v", err)
+	}
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	// git returns top-level directory with resolved symlinks
+	if resolved, err := filepath.EvalSymlinks(filepath.Dir(absolutePath)); err == nil {
+		absolutePath = filepath.Join(resolved, filepath.Base(absolutePath))
+	}
+	relativePath, err := filepath.Rel(strings.TrimSpace(string(topLevel)), absolutePath)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside of git repository %s", path, strings.TrimSpace(string(topLevel)))
+	}
+	return filepath.ToSlash(relativePath), nil
+}
+
+func countRegressions(changes []score.Change) int {
+	regressions := 0
+	for _, change := range changes {
+		if change.IsRegression {
+			regressions++
+		}
+	}
+	return regressions
+}
+
+func printScoreChanges(changes []score.Change) {
+	result := &output.Result{Header: scoreDiffHeaders, Rows: scoreChangesTable(changes), Value: changes}
+	if err := output.Print(result); err != nil {
+		log.Errorf("error occurred on output: %v", err)
+	}
+}
+
+func scoreChangesTable(changes []score.Change) [][]string {
+	data := make([][]string, 0, len(changes))
+	for _, change := range changes {
+		category, issues, regression := change.Category, "", ""
+		if category == "" {
+			category = "document"
+		} else {
+			issues = fmt.Sprintf("%d -> %d", change.PreviousIssues, change.CurrentIssues)
+		}
+		if change.IsRegression {
+			regression = "yes"
+		}
+		data = append(data, []string{change.File, change.Version, category,
+			fmt.Sprintf("%.2f", change.Previous), fmt.Sprintf("%.2f", change.Current), issues, regression})
+	}
+	return data
+}
@@ -0,0 +1,103 @@
+package info
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/mock"
+	"github.com/qordobacode/cli-v2/pkg/score"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestSelectScoreFiles(t *testing.T) {
+	files := []types.File{
+		{Filename: "en.json", Version: "v1", Tags: []types.Tags{{Name: "release"}}},
+		{Filename: "app.yaml", Version: "v1"},
+		{Filename: "old.json", Version: "v1", Deleted: true},
+		{Filename: "en.json", Version: "v2"},
+	}
+	assert.Len(t, selectScoreFiles(files, nil), 3)
+	assert.Len(t, selectScoreFiles(files, []string{"*.json"}), 2)
+
+	scoreFileVersion = "v1"
+	assert.Equal(t, files[:1], selectScoreFiles(files, []string{"en.json"}))
+	scoreFileVersion, scoreTag = "", "release"
+	assert.Equal(t, files[:1], selectScoreFiles(files, nil))
+	scoreTag = ""
+}
+
+func TestScoreTable(t *testing.T) {
+	scores := []*fileScore{
+		{File: "en.json", Version: "v1", Score: 7.5, Breakdown: []types.Breakdown{
+			{Category: "Grammar", IssueCount: 2, Score: 8, Enabled: true},
+			{Category: "Style", IssueCount: 1, Score: 9, Enabled: false},
+		}},
+		{File: "app.yaml", Score: 9, Breakdown: []types.Breakdown{{Category: "Clarity", IssueCount: 0, Score: 10, Enabled: true}}},
+	}
+	header, data := scoreTable(scores)
+	assert.Equal(t, []string{"FILE NAME", "#VERSION", "SCORE", "Clarity", "Grammar"}, header)
+	assert.Equal(t, [][]string{
+		{"en.json", "v1", "7.50", "", "8.00 (2 issues)"},
+		{"app.yaml", "", "9.00", "10.00 (0 issues)", ""},
+	}, data)
+
+	scoreMin = 8
+	assert.Equal(t, []string{"en.json v1"}, belowMinScore(scores))
+	scoreMin = 0
+}
+
+func TestScoreSelectedFiles(t *testing.T) {
+	files := mock.NewMockFileService(gomock.NewController(t))
+	application := &app.App{FileService: files}
+	selected := []types.File{{FileID: 1, Filename: "en.json"}, {FileID: 2, Filename: "app.yaml", Version: "v2"}, {FileID: 3, Filename: "es.json"}}
+	files.EXPECT().ScoreFile(&selected[0], 3).Return(&types.ScoreResponseBody{DocumentScore: 9}, nil)
+	files.EXPECT().ScoreFile(&selected[1], 3).Return(nil, errors.New("internal server error"))
+	files.EXPECT().ScoreFile(&selected[2], 3).Return(&types.ScoreResponseBody{DocumentScore: 7}, nil)
+
+	scores, unscored := scoreSelectedFiles(application, selected, 3)
+	assert.Len(t, scores, 2)
+	assert.Equal(t, "es.json", scores[1].File)
+	assert.Equal(t, float64(7), scores[1].Score)
+	assert.Equal(t, []string{"app.yaml v2"}, unscored)
+}
+
+func TestScoreChangesTable(t *testing.T) {
+	data := scoreChangesTable([]score.Change{
+		{File: "en.json", Previous: 9, Current: 7, IsRegression: true},
+		{File: "en.json", Category: "Grammar", Previous: 8, Current: 9, PreviousIssues: 2, CurrentIssues: 1},
+	})
+	assert.Equal(t, [][]string{
+		{"en.json", "", "document", "9.00", "7.00", "", "yes"},
+		{"en.json", "", "Grammar", "8.00", "9.00", "2 -> 1", ""},
+	}, data)
+	assert.Equal(t, 1, countRegressions([]score.Change{{IsRegression: true}, {}}))
+}
+
+func TestRepositoryPath(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir, err := ioutil.TempDir("", "score-repo")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "repo", "sub"), 0755))
+	assert.Nil(t, exec.Command("git", "init", filepath.Join(dir, "repo")).Run())
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	assert.Nil(t, os.Chdir(filepath.Join(dir, "repo", "sub")))
+
+	path, err := repositoryPath("history.json")
+	assert.Nil(t, err)
+	assert.Equal(t, "sub/history.json", path)
+	path, err = repositoryPath(filepath.Join(dir, "repo", "history.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, "history.json", path)
+	_, err = repositoryPath(filepath.Join(dir, "history.json"))
+	assert.NotNil(t, err)
+}
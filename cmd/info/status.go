@@ -1,14 +1,17 @@
 package info
 
 import (
-	"encoding/json"
 	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
 	"github.com/qordobacode/cli-v2/pkg/types"
+	"math"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
-	"strconv"
 )
 
 const (
@@ -16,210 +19,238 @@ const (
 )
 
 var (
-	basicHeaders = []string{
-		"#AUDIENSES", "#SEGMENTS", "#WORDS",
-	}
-	fileHeaders = []string{
-		"FILE NAME", "#SEGMENTS", "#WORDS",
-	}
 	statusFileVersion string
+	statusAudiences   []string
+	statusTag         string
+	isStatusByFile    bool
 )
 
+// statusRow is a progress of a file or of all files of audience. Progress is a percent of segments in every workflow step
+type statusRow struct {
+	Audience  string             `json:"audience"`
+	File      string             `json:"file,omitempty"`
+	Version   string             `json:"version,omitempty"`
+	Segments  int                `json:"segments"`
+	Words     int                `json:"words"`
+	Progress  map[string]float64 `json:"progress"`
+	Completed bool               `json:"completed"`
+	counts    map[string]int
+	files     int
+}
+
 // NewStatusCommand creates `status` command
-func NewStatusCommand() *cobra.Command {
+func NewStatusCommand(application *app.App) *cobra.Command {
 	statusCmd := &cobra.Command{
 		Annotations: map[string]string{"group": "info"},
-		Use:         "status",
+		Use:         "status [file]",
 		Short:       "Status per project or file (Support file versions)",
-		Example:     `"qor status", "qor status --json", "qor status filename.docx --version 0.2"`,
-		Run:         runStatus,
-		PreRun:      startLocalServices,
+		Long:        "Prints percent of segments in every workflow step for every audience, for every file with `--by-file` flag or for a single file",
+		Example: `qor status
+qor status --by-file --audience fr-fr,de-de --tag release --output markdown
+qor status filename.docx --version 0.2 --json`,
+		Run:    func(cmd *cobra.Command, args []string) { runStatus(application, cmd, args) },
+		PreRun: startLocalServices(application),
 	}
-	statusCmd.Flags().StringVarP(&statusFileVersion, "version", "v", "", "--version")
-	statusCmd.PersistentFlags().BoolVar(&IsJSON, "json", false, "Print output in JSON format")
+	statusCmd.Flags().StringVarP(&statusFileVersion, "version", "v", "", "version of files")
+	statusCmd.Flags().StringSliceVarP(&statusAudiences, "audience", "a", nil, "target persona codes (default all target personas)")
+	statusCmd.Flags().StringVar(&statusTag, "tag", "", "only files with tag")
+	statusCmd.Flags().BoolVar(&isStatusByFile, "by-file", false, "print status of every file")
 	return statusCmd
-
 }
 
-func runStatus(cmd *cobra.Command, args []string) {
-	if appConfig == nil {
+func runStatus(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
 		log.Errorf("error occurred on configuration load")
 		return
 	}
-	workspace, err := workspaceService.LoadWorkspace()
+	workspace, err := application.WorkspaceService.LoadWorkspace()
 	if err != nil || workspace == nil {
 		return
 	}
+	personas, err := statusPersonas(workspace.Workspace.TargetPersonas)
+	if err != nil {
+		log.Errorf("%v", err)
+		return
+	}
+	fileName := ""
 	if len(args) > 0 {
-		runFileStatusFile(args[0], workspace)
-	} else {
-		buildProjectStatus(workspace)
+		fileName = args[0]
 	}
-}
-
-func buildProjectStatus(workspace *types.WorkspaceData) {
-	fileSearchResponse := getFileSearchResponse(&workspace.Workspace)
-	if fileSearchResponse == nil {
-		log.Errorf("fileSearchResponse %v not found", workspace.Workspace.ID)
+	rows, steps, err := statusRows(application, personas, fileName)
+	if err != nil {
+		log.Errorf("error occurred on files load: %v", err)
 		return
 	}
-	if len(fileSearchResponse.ByPersonaProgress) == 0 {
-		log.Errorf("fileSearchResponse returned empty byPersonaProgress. Status is not available")
+	if fileName != "" && len(rows) == 0 {
+		log.Errorf("file %s('%s') was not found", fileName, statusFileVersion)
 		return
 	}
-	progress := fileSearchResponse.ByPersonaProgress[0].ByWorkflowProgress
-	reorderProgressStatuses(fileSearchResponse)
-	header := buildTableHeader(progress, basicHeaders)
-	dataRow, dataJSON := buildTableData(fileSearchResponse.ByPersonaProgress, header)
-	printProjectStatus2Stdin(header, dataRow, dataJSON)
+	printStatus(rows, steps, isStatusByFile || fileName != "")
 }
 
-func reorderProgressStatuses(fileSearchResponse *types.FileSearchResponse) {
-	progresses := fileSearchResponse.ByPersonaProgress
-	for k, _ := range progresses {
-		sort.Slice(progresses[k].ByWorkflowProgress, func(i, j int) bool {
-			return progresses[k].ByWorkflowProgress[i].Workflow.Order < progresses[k].ByWorkflowProgress[j].Workflow.Order
-		})
+func statusPersonas(targetPersonas []types.Person) ([]types.Person, error) {
+	if len(statusAudiences) == 0 {
+		return targetPersonas, nil
 	}
-}
-
-func printProjectStatus2Stdin(header []string, tableData [][]string, dataJSON []map[string]string) {
-	if !IsJSON {
-		local.RenderTable2Stdin(header, tableData)
-		return
+	personas := make([]types.Person, 0, len(statusAudiences))
+	targetCodes := make([]string, 0, len(targetPersonas))
+	for _, persona := range targetPersonas {
+		targetCodes = append(targetCodes, "`"+persona.Code+"`")
 	}
-	bytes, err := json.MarshalIndent(dataJSON, "", "  ")
-	if err != nil {
-		log.Errorf("error occurred on marshalling with JSON: %v", err)
-		return
+	for _, code := range statusAudiences {
+		found := false
+		for _, persona := range targetPersonas {
+			if persona.Code == code {
+				personas = append(personas, persona)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("`%s` does not match one of available project target languages: %s", code, strings.Join(targetCodes, ", "))
+		}
 	}
-	log.Infof("%v", string(bytes))
+	return personas, nil
 }
 
-func getFileSearchResponse(response *types.Workspace) *types.FileSearchResponse {
-	for _, person := range response.TargetPersonas {
-		fileSearchResponse, err := fileService.WorkspaceFiles(person.ID, true)
+// statusRows returns a row for every file of every persona if files are printed, or a row for every persona otherwise.
+// Workflow steps are returned in the order of workflow with completed step at the end
+func statusRows(application *app.App, personas []types.Person, fileName string) ([]*statusRow, []string, error) {
+	rows := make([]*statusRow, 0)
+	workflows := make(map[string]types.Workflow)
+	isByFile := isStatusByFile || fileName != ""
+	for _, persona := range personas {
+		response, err := application.FileService.WorkspaceFiles(persona.ID, true)
 		if err != nil {
-			continue
+			return nil, nil, err
 		}
-		body, err := json.MarshalIndent(fileSearchResponse, "  ", "  ")
-		if err != nil {
-			log.Debugf("person %v fileSearchResponse\n%s", person.Code, string(body))
+		personaRow := newStatusRow(persona.Code)
+		for i := range response.Files {
+			file := &response.Files[i]
+			if !isStatusFile(file, fileName) {
+				continue
+			}
+			for _, progress := range file.ByWorkflowProgress {
+				workflows[stepName(progress.Workflow)] = progress.Workflow
+			}
+			if isByFile {
+				fileRow := newStatusRow(persona.Code)
+				fileRow.File, fileRow.Version = file.Filename, file.Version
+				fileRow.add(file)
+				rows = append(rows, fileRow)
+			} else {
+				personaRow.add(file)
+			}
+		}
+		if !isByFile {
+			rows = append(rows, personaRow)
 		}
-		return fileSearchResponse
 	}
-	return nil
+	steps := sortedSteps(workflows)
+	for _, row := range rows {
+		for _, step := range steps {
+			row.Progress[step] = percent(row.counts[step], row.Segments)
+		}
+	}
+	return rows, steps, nil
 }
 
-func buildTableHeader(workflowProgress []types.ByWorkflowProgress, headers []string) []string {
-	result := make([]string, 0, len(workflowProgress)+len(headers))
-	result = append(result, headers...)
-	completedVal := false
-	for _, workflowState := range workflowProgress {
-		if workflowState.Workflow.Complete {
-			completedVal = true
-		} else {
-			result = append(result, workflowState.Workflow.Name)
+func isStatusFile(file *types.File, fileName string) bool {
+	if file.Deleted || (fileName != "" && file.Filename != fileName) {
+		return false
+	}
+	if statusFileVersion != "" && file.Version != statusFileVersion {
+		return false
+	}
+	if statusTag == "" {
+		return true
+	}
+	for _, tag := range file.Tags {
+		if tag.Name == statusTag {
+			return true
 		}
 	}
-	if completedVal {
-		result = append(result, completed)
+	return false
+}
+
+func newStatusRow(audience string) *statusRow {
+	return &statusRow{
+		Audience: audience,
+		Progress: make(map[string]float64),
+		counts:   make(map[string]int),
 	}
-	return result
 }
 
-func buildTableData(personaProgress []types.ByPersonaProgress, header []string) ([][]string, []map[string]string) {
-	data := make([][]string, 0, len(personaProgress))
-	dataJSON := make([]map[string]string, 0, len(personaProgress))
-	for _, progress := range personaProgress {
-		row, rowJSON := buildTableRow(&progress, header)
-		data = append(data, row)
-		dataJSON = append(dataJSON, rowJSON)
+func (r *statusRow) add(file *types.File) {
+	words, segments := countTotalSegments(file)
+	r.Words += words
+	r.Segments += segments
+	r.Completed = (r.files == 0 || r.Completed) && file.Completed
+	r.files++
+	for _, progress := range file.ByWorkflowProgress {
+		r.counts[stepName(progress.Workflow)] += progress.Counts.SegmentCount
 	}
-	return data, dataJSON
 }
 
-func buildTableRow(personProgress *types.ByPersonaProgress, header []string) ([]string, map[string]string) {
-	row := make([]string, len(header))
-	rowMap := make(map[string]string)
-	row[0] = personProgress.Persona.Code
-	rowMap["audiences_id"] = strconv.Itoa(personProgress.Persona.ID)
-	rowMap["audiences_code"] = personProgress.Persona.Code
-	rowMap["audiences_name"] = personProgress.Persona.Name
-
-	totalWords := 0
-	totalSegments := 0
-	for _, workflowProgress := range personProgress.ByWorkflowProgress {
-		totalWords += workflowProgress.Counts.WordCount
-		totalSegments += workflowProgress.Counts.SegmentCount
-	}
-	row[1] = strconv.Itoa(totalSegments)
-	rowMap["segments_total"] = row[1]
-	row[2] = strconv.Itoa(totalWords)
-	rowMap["words_total"] = row[2]
-	i := 0
-	completedVal := ""
-	// same order in iteration as it was on header filling step
-	for _, workflowProgress := range personProgress.ByWorkflowProgress {
-		percent := float64(workflowProgress.Counts.SegmentCount) / float64(totalSegments) * 100
-		val := fmt.Sprintf(`%6.2f%%`, percent)
-		if workflowProgress.Workflow.Complete {
-			///////rowMap[completed] = val*
-			completedVal = val
-		} else {
-			row[i+3] = val
-			rowMap[workflowProgress.Workflow.Name] = row[i+3]
-			i++
-		}
+// stepName joins all completed workflow steps into `Complete` column
+func stepName(workflow types.Workflow) string {
+	if workflow.Complete {
+		return completed
+	}
+	return workflow.Name
+}
+
+func sortedSteps(workflows map[string]types.Workflow) []string {
+	steps := make([]string, 0, len(workflows))
+	for step := range workflows {
+		steps = append(steps, step)
 	}
-	if completedVal != "" {
-		row[i+3] = completedVal
+	sort.Slice(steps, func(i, j int) bool {
+		first, second := workflows[steps[i]], workflows[steps[j]]
+		if first.Complete != second.Complete {
+			return second.Complete
+		}
+		if first.Order != second.Order {
+			return first.Order < second.Order
+		}
+		return steps[i] < steps[j]
+	})
+	return steps
+}
+
+// percent returns rounded percent of segments. No segments is 0%
+func percent(count, total int) float64 {
+	if total == 0 {
+		return 0
 	}
-	return row, rowMap
+	return math.Round(float64(count)/float64(total)*10000) / 100
 }
 
-func runFileStatusFile(fileName string, workspace *types.WorkspaceData) {
-	fileSearchResponse, _ := fileService.FindFile(fileName, statusFileVersion, true)
-	if fileSearchResponse == nil {
-		log.Debugf("file %s('%s') was not found", fileName, statusFileVersion)
-		return
+func printStatus(rows []*statusRow, steps []string, withFile bool) {
+	header, data := statusTableData(rows, steps, withFile)
+	if err := output.Print(&output.Result{Header: header, Rows: data, Value: rows}); err != nil {
+		log.Errorf("error occurred on output: %v", err)
 	}
-	header := buildTableHeader(fileSearchResponse.ByWorkflowProgress, fileHeaders)
-	data, dataJSON := buildFileTableData(fileSearchResponse)
-	printProjectStatus2Stdin(header, data, dataJSON)
 }
 
-func buildFileTableData(file *types.File) ([][]string, []map[string]string) {
-	data := make([][]string, 0, len(file.ByWorkflowProgress))
-	dataJSON := make([]map[string]string, 0, len(file.ByWorkflowProgress))
-	fileRow := make([]string, 0, 0)
-	JSONdocument := make(map[string]string)
-	words, segments := countTotalSegments(file)
-	fileRow = append(fileRow, file.Filename+" "+file.Version)
-	fileRow = append(fileRow, strconv.Itoa(segments))
-	JSONdocument["segments"] = fileRow[1]
-	fileRow = append(fileRow, strconv.Itoa(words))
-	JSONdocument["words"] = fileRow[2]
-	JSONdocument["file_name"] = file.Filename
-	JSONdocument["version"] = file.Version
-	JSONdocument["file_id"] = strconv.Itoa(file.FileID)
-	if file.Completed {
-		JSONdocument["completed"] = strconv.FormatBool(file.Completed)
-	}
-	i := 0
-	for _, workflowProgress := range file.ByWorkflowProgress {
-		val := "0"
-		if segments != 0 {
-			percent := float64(workflowProgress.Counts.SegmentCount) / float64(segments) * 100
-			val = fmt.Sprintf(`%6.2f%%`, percent)
+func statusTableData(rows []*statusRow, steps []string, withFile bool) ([]string, [][]string) {
+	header := []string{"AUDIENCE"}
+	if withFile {
+		header = append(header, "FILE NAME", "#VERSION")
+	}
+	header = append(append(header, "#SEGMENTS", "#WORDS"), steps...)
+	data := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		line := []string{row.Audience}
+		if withFile {
+			line = append(line, row.File, row.Version)
 		}
-		fileRow = append(fileRow, val)
-		JSONdocument[workflowProgress.Workflow.Name] = fileRow[i+3]
-		i++
+		line = append(line, strconv.Itoa(row.Segments), strconv.Itoa(row.Words))
+		for _, step := range steps {
+			line = append(line, fmt.Sprintf("%.2f%%", row.Progress[step]))
+		}
+		data = append(data, line)
 	}
-	data = append(data, fileRow)
-
-	return data, dataJSON
+	return header, data
 }
 
 func countTotalSegments(file *types.File) (words int, segments int) {
@@ -0,0 +1,65 @@
+package info
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/mock"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func statusFile(name string, isCompleted bool, translation, review, complete int) types.File {
+	file := progressFile(name, isCompleted, translation, review, complete)
+	for i := range file.ByWorkflowProgress {
+		file.ByWorkflowProgress[i].Counts.WordCount = file.ByWorkflowProgress[i].Counts.SegmentCount * 2
+	}
+	return file
+}
+
+func startStatus(t *testing.T) (*app.App, []types.Person) {
+	files := mock.NewMockFileService(gomock.NewController(t))
+	application := &app.App{FileService: files}
+	files.EXPECT().WorkspaceFiles(3, true).AnyTimes().Return(&types.FileSearchResponse{Files: []types.File{
+		statusFile("en.json", false, 1, 1, 2),
+		statusFile("app.yaml", true, 0, 0, 4),
+		{Filename: "empty.json"},
+	}}, nil)
+	files.EXPECT().WorkspaceFiles(4, true).AnyTimes().Return(&types.FileSearchResponse{Files: []types.File{
+		statusFile("en.json", true, 0, 0, 4),
+	}}, nil)
+	return application, []types.Person{{ID: 3, Code: "fr-fr"}, {ID: 4, Code: "de-de"}}
+}
+
+func TestStatusRows(t *testing.T) {
+	application, personas := startStatus(t)
+	rows, steps, err := statusRows(application, personas, "")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Translation", "Review", completed}, steps)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, 8, rows[0].Segments)
+	assert.Equal(t, 16, rows[0].Words)
+	assert.Equal(t, map[string]float64{"Translation": 12.5, "Review": 12.5, completed: 75}, rows[0].Progress)
+	assert.False(t, rows[0].Completed)
+	assert.True(t, rows[1].Completed)
+
+	rows, _, err = statusRows(application, personas, "empty.json")
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, map[string]float64{}, rows[0].Progress)
+}
+
+func TestStatusRowsByFile(t *testing.T) {
+	application, personas := startStatus(t)
+	isStatusByFile = true
+	defer func() { isStatusByFile = false }()
+	rows, steps, err := statusRows(application, personas, "")
+	assert.Nil(t, err)
+	assert.Len(t, rows, 4)
+	assert.Equal(t, float64(0), rows[2].Progress["Translation"])
+
+	header, data := statusTableData(rows[:1], steps, true)
+	assert.Equal(t, []string{"AUDIENCE", "FILE NAME", "#VERSION", "#SEGMENTS", "#WORDS", "Translation", "Review", completed}, header)
+	assert.Equal(t, []string{"fr-fr", "en.json", "", "4", "8", "25.00%", "25.00%", "50.00%"}, data[0])
+
+}
@@ -2,6 +2,8 @@ package info
 
 import (
 	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
 
 	"github.com/spf13/cobra"
 )
@@ -27,7 +29,15 @@ func NewCmdVersion() *cobra.Command {
 	}
 }
 
-// PrintVersion function print current version to stdout
+// PrintVersion function print current version to stdout. Structured output formats get version as `version` field
 func PrintVersion() {
-	fmt.Printf("%s-%s\n", APIVersion, VersionFlag)
+	version := APIVersion + "-" + VersionFlag
+	if output.Current() == output.Table {
+		fmt.Fprintln(output.Stdout, version)
+		return
+	}
+	result := &output.Result{Header: []string{"version"}, Rows: [][]string{{version}}, Value: map[string]string{"version": version}}
+	if err := output.Print(result); err != nil {
+		log.Errorf("error occurred on output: %v", err)
+	}
 }
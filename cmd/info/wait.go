@@ -0,0 +1,243 @@
+package info
+
+import (
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/segments"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	waitAudiences []string
+	waitFiles     []string
+	waitVersion   string
+	waitStep      string
+	waitPercent   float64
+	waitTimeout   time.Duration
+	waitInterval  time.Duration
+	waitHeaders   = []string{"AUDIENCE", "FILE NAME", "#VERSION", "PROGRESS", "DONE"}
+)
+
+// waitStatus is a progress of a single file for a single persona
+type waitStatus struct {
+	Audience string  `json:"audience"`
+	File     string  `json:"file"`
+	Version  string  `json:"version,omitempty"`
+	Progress float64 `json:"progress"`
+	Done     bool    `json:"done"`
+	Missing  bool    `json:"missing"`
+}
+
+// NewWaitCommand creates `wait` command
+func NewWaitCommand(application *app.App) *cobra.Command {
+	waitCmd := &cobra.Command{
+		Annotations: map[string]string{"group": "info"},
+		Use:         "wait",
+		Short:       "Wait until translations are complete",
+		Long: "Polls files progress until all selected files are completed (or reach `--percent` of segments in `--step` workflow step and further) " +
+			"for all selected audiences. Exits with non-zero code on timeout",
+		Example: `qor wait --audience fr-fr,de-de --files en.json,app.yaml --timeout 2h --interval 1m
+qor wait --step Review --percent 100`,
+		Args:   cobra.NoArgs,
+		PreRun: startLocalServices(application),
+		Run:    func(cmd *cobra.Command, args []string) { runWait(application, cmd, args) },
+	}
+	waitCmd.Flags().StringSliceVarP(&waitAudiences, "audience", "a", nil, "target persona codes to wait for (default audiences from config or all target personas)")
+	waitCmd.Flags().StringSliceVar(&waitFiles, "files", nil, "file names to wait for (default all files)")
+	waitCmd.Flags().StringVarP(&waitVersion, "version", "v", "", "version of files")
+	waitCmd.Flags().StringVar(&waitStep, "step", "", "workflow step to wait for instead of file completion")
+	waitCmd.Flags().Float64Var(&waitPercent, "percent", 100, "percent of segments in workflow step and further to wait for")
+	waitCmd.Flags().DurationVar(&waitTimeout, "timeout", time.Hour, "maximum time to wait")
+	waitCmd.Flags().DurationVar(&waitInterval, "interval", time.Minute, "interval between status checks")
+	return waitCmd
+}
+
+func runWait(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
+		log.Errorf("error occurred on configuration load")
+		return
+	}
+	workspace, err := application.WorkspaceService.LoadWorkspace()
+	if err != nil || workspace == nil {
+		os.Exit(1)
+	}
+	personas, err := waitPersonas(application, workspace.Workspace.TargetPersonas)
+	if err != nil {
+		log.Errorf("%v", err)
+		os.Exit(1)
+	}
+	var step *types.Workflow
+	if waitStep != "" {
+		if step, err = segments.FindWorkflowStep(workspace.Workflow, waitStep); err != nil {
+			log.Errorf("%v", err)
+			os.Exit(1)
+		}
+	}
+	statuses, ok := waitFor(application, personas, step)
+	if err := output.Print(&output.Result{Header: waitHeaders, Rows: waitStatusTable(statuses), Value: statuses}); err != nil {
+		log.Errorf("error occurred on output: %v", err)
+	}
+	if !ok {
+		log.Errorf("Translations were not complete in %v", waitTimeout)
+		os.Exit(1)
+	}
+	log.Infof("Translations are complete")
+}
+
+// waitFor polls files progress until all of them are done or timeout is reached.
+// The last check is made right at the deadline, even if it's closer than interval
+func waitFor(application *app.App, personas []types.Person, step *types.Workflow) ([]*waitStatus, bool) {
+	deadline := application.Now().Add(waitTimeout)
+	for {
+		statuses := checkProgress(application, personas, step)
+		if isAllDone(statuses) {
+			return statuses, true
+		}
+		remaining := deadline.Sub(application.Now())
+		if remaining <= 0 {
+			return statuses, false
+		}
+		pause := waitInterval
+		if remaining < pause {
+			pause = remaining
+		}
+		log.Debugf("%d of %d files are done, next check in %v", countDone(statuses), len(statuses), pause)
+		application.Sleep(pause)
+	}
+}
+
+func waitPersonas(application *app.App, targetPersonas []types.Person) ([]types.Person, error) {
+	codes := waitAudiences
+	if len(codes) == 0 {
+		for code := range application.Config.Audiences() {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return targetPersonas, nil
+	}
+	selected := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		selected[code] = true
+	}
+	personas := make([]types.Person, 0, len(codes))
+	targetCodes := make([]string, 0, len(targetPersonas))
+	for _, persona := range targetPersonas {
+		targetCodes = append(targetCodes, "`"+persona.Code+"`")
+		if selected[persona.Code] {
+			personas = append(personas, persona)
+			delete(selected, persona.Code)
+		}
+	}
+	for code := range selected {
+		return nil, fmt.Errorf("`%s` does not match one of available project target languages: %s", code, strings.Join(targetCodes, ", "))
+	}
+	return personas, nil
+}
+
+func checkProgress(application *app.App, personas []types.Person, step *types.Workflow) []*waitStatus {
+	statuses := make([]*waitStatus, 0)
+	for _, persona := range personas {
+		response, err := application.FileService.WorkspaceFiles(persona.ID, true)
+		if err != nil {
+			log.Errorf("error occurred on files load for %s: %v", persona.Code, err)
+			statuses = append(statuses, &waitStatus{Audience: persona.Code, Missing: true})
+			continue
+		}
+		statuses = append(statuses, fileStatuses(persona.Code, response.Files, step)...)
+	}
+	return statuses
+}
+
+// fileStatuses returns status of every selected file. Selected files absent on server are reported as missing
+func fileStatuses(audience string, files []types.File, step *types.Workflow) []*waitStatus {
+	statuses := make([]*waitStatus, 0)
+	found := make(map[string]bool)
+	for i := range files {
+		file := &files[i]
+		if file.Deleted || !isWaitedFile(file) {
+			continue
+		}
+		found[file.Filename] = true
+		progress := fileProgress(file, step)
+		statuses = append(statuses, &waitStatus{
+			Audience: audience,
+			File:     file.Filename,
+			Version:  file.Version,
+			Progress: progress,
+			Done:     (step == nil && file.Completed) || (step != nil && progress >= waitPercent),
+		})
+	}
+	for _, name := range waitFiles {
+		if !found[name] {
+			statuses = append(statuses, &waitStatus{Audience: audience, File: name, Version: waitVersion, Missing: true})
+		}
+	}
+	return statuses
+}
+
+func isWaitedFile(file *types.File) bool {
+	if waitVersion != "" && file.Version != waitVersion {
+		return false
+	}
+	if len(waitFiles) == 0 {
+		return true
+	}
+	for _, name := range waitFiles {
+		if name == file.Filename {
+			return true
+		}
+	}
+	return false
+}
+
+// fileProgress returns percent of file segments in provided workflow step and further steps,
+// or in completed steps when step is nil
+func fileProgress(file *types.File, step *types.Workflow) float64 {
+	_, segmentCount := countTotalSegments(file)
+	if segmentCount == 0 {
+		if file.Completed {
+			return 100
+		}
+		return 0
+	}
+	reached := 0
+	for _, progress := range file.ByWorkflowProgress {
+		if progress.Workflow.Complete || (step != nil && progress.Workflow.Order >= step.Order) {
+			reached += progress.Counts.SegmentCount
+		}
+	}
+	return float64(reached) / float64(segmentCount) * 100
+}
+
+func isAllDone(statuses []*waitStatus) bool {
+	return len(statuses) > 0 && countDone(statuses) == len(statuses)
+}
+
+func countDone(statuses []*waitStatus) int {
+	done := 0
+	for _, status := range statuses {
+		if status.Done {
+			done++
+		}
+	}
+	return done
+}
+
+func waitStatusTable(statuses []*waitStatus) [][]string {
+	data := make([][]string, 0, len(statuses))
+	for _, status := range statuses {
+		progress := fmt.Sprintf("%6.2f%%", status.Progress)
+		if status.Missing {
+			progress = "not found"
+		}
+		data = append(data, []string{status.Audience, status.File, status.Version, progress, fmt.Sprint(status.Done)})
+	}
+	return data
+}
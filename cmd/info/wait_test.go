@@ -0,0 +1,95 @@
+package info
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/mock"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func progressFile(name string, completed bool, translation, review, complete int) types.File {
+	return types.File{
+		Filename:  name,
+		Completed: completed,
+		ByWorkflowProgress: []types.ByWorkflowProgress{
+			{Workflow: types.Workflow{Name: "Translation", Order: 0}, Counts: types.Counts{SegmentCount: translation}},
+			{Workflow: types.Workflow{Name: "Review", Order: 1}, Counts: types.Counts{SegmentCount: review}},
+			{Workflow: types.Workflow{Name: "Complete", Order: 2, Complete: true}, Counts: types.Counts{SegmentCount: complete}},
+		},
+	}
+}
+
+func TestFileStatuses(t *testing.T) {
+	waitFiles = []string{"en.json", "absent.json"}
+	defer func() { waitFiles = nil }()
+	files := []types.File{
+		progressFile("en.json", false, 1, 2, 1),
+		progressFile("other.json", false, 4, 0, 0),
+	}
+	statuses := fileStatuses("fr-fr", files, nil)
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, &waitStatus{Audience: "fr-fr", File: "en.json", Progress: 25}, statuses[0])
+	assert.True(t, statuses[1].Missing)
+
+	waitPercent = 75
+	statuses = fileStatuses("fr-fr", files, &types.Workflow{Name: "Review", Order: 1})
+	assert.Equal(t, float64(75), statuses[0].Progress)
+	assert.True(t, statuses[0].Done)
+	waitPercent = 100
+}
+
+func TestWaitFor(t *testing.T) {
+	controller := gomock.NewController(t)
+	files := mock.NewMockFileService(controller)
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	application := app.New(app.WithClock(func() time.Time { return now }, func(d time.Duration) { now = now.Add(d) }))
+	application.FileService = files
+	waitTimeout, waitInterval = 3*time.Minute, time.Minute
+	personas := []types.Person{{ID: 3, Code: "fr-fr"}}
+
+	gomock.InOrder(
+		files.EXPECT().WorkspaceFiles(3, true).Return(&types.FileSearchResponse{Files: []types.File{progressFile("en.json", false, 1, 0, 0)}}, nil),
+		files.EXPECT().WorkspaceFiles(3, true).Return(&types.FileSearchResponse{Files: []types.File{progressFile("en.json", true, 0, 0, 1)}}, nil),
+	)
+	statuses, ok := waitFor(application, personas, nil)
+	assert.True(t, ok)
+	assert.Equal(t, float64(100), statuses[0].Progress)
+	assert.Equal(t, time.Minute, now.Sub(time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)))
+
+	files.EXPECT().WorkspaceFiles(3, true).Times(4).Return(&types.FileSearchResponse{Files: []types.File{progressFile("en.json", false, 1, 0, 0)}}, nil)
+	_, ok = waitFor(application, personas, nil)
+	assert.False(t, ok)
+}
+
+func TestWaitFor_ChecksAtDeadline(t *testing.T) {
+	defer func() { waitTimeout, waitInterval = time.Hour, time.Minute }()
+	tests := []struct {
+		timeout  time.Duration
+		interval time.Duration
+		checks   []time.Duration
+	}{
+		{2 * time.Hour, time.Hour, []time.Duration{0, time.Hour, 2 * time.Hour}},
+		{time.Hour, time.Hour, []time.Duration{0, time.Hour}},
+		{90 * time.Second, time.Minute, []time.Duration{0, time.Minute, 90 * time.Second}},
+	}
+	for _, test := range tests {
+		files := mock.NewMockFileService(gomock.NewController(t))
+		start := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+		now := start
+		application := app.New(app.WithClock(func() time.Time { return now }, func(d time.Duration) { now = now.Add(d) }))
+		application.FileService = files
+		waitTimeout, waitInterval = test.timeout, test.interval
+		checks := make([]time.Duration, 0)
+		files.EXPECT().WorkspaceFiles(3, true).AnyTimes().DoAndReturn(func(personaID int, withProgressStatus bool) (*types.FileSearchResponse, error) {
+			checks = append(checks, now.Sub(start))
+			return &types.FileSearchResponse{Files: []types.File{progressFile("en.json", false, 1, 0, 0)}}, nil
+		})
+
+		_, ok := waitFor(application, []types.Person{{ID: 3, Code: "fr-fr"}}, nil)
+		assert.False(t, ok)
+		assert.Equal(t, test.checks, checks, "timeout %v, interval %v", test.timeout, test.interval)
+	}
+}
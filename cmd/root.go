@@ -6,20 +6,40 @@ import (
 	"github.com/qordobacode/cli-v2/cmd/file"
 	"github.com/qordobacode/cli-v2/cmd/info"
 	"github.com/qordobacode/cli-v2/cmd/segment"
+	"github.com/qordobacode/cli-v2/cmd/server"
+	"github.com/qordobacode/cli-v2/pkg/app"
 	pkgconf "github.com/qordobacode/cli-v2/pkg/config"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/rest"
 	"os"
 
 	"github.com/spf13/cobra"
 )
 
-// rootCmd represents the base command when called without any subcommands
 var (
-	rootCmd = &cobra.Command{
+	// Version is set by `--version` flag
+	Version   bool
+	logLevel  string
+	logFormat string
+	logFile   string
+)
+
+// NewRootCmd builds the base command with all child commands sharing one application container created with
+// options. Every call binds flags anew, resetting their values
+func NewRootCmd(options ...app.Option) *cobra.Command {
+	application := app.New(options...)
+	rootCmd := &cobra.Command{
 		Use:     "qor",
 		Short:   "Qordoba CLI",
 		Long:    `This CLI is used for simplified access to Qordoba API`,
 		Version: info.APIVersion + "-" + info.VersionFlag,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := log.Configure(logLevel, logFormat, logFile); err != nil {
+				return err
+			}
+			return output.Validate()
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			if Version {
 				info.PrintVersion()
@@ -28,42 +48,62 @@ var (
 			}
 		},
 	}
-	Version bool
-)
-
-// Execute adds all child commands to the root command and sets flags appropriately.
-// This is called by main.main(). It only needs to happen once to the rootCmd.
-func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-}
-
-func init() {
-	// Here you will define your flags and configuration settings.
-	// Cobra supports persistent flags, which, if defined here,
-	// will be global for your application.
-	//rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.test.yaml)")
 	rootCmd.Flags().BoolVarP(&Version, "version", "v", false, "GET version of CLI")
 	rootCmd.PersistentFlags().BoolVar(&log.IsVerbose, "verbose", false, "Print verbose output")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", log.InfoLevel.String(), "Log level: debug, info, warn or error")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", log.TextFormat, "Log format: text or json")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Append logs with timestamps into file in addition to stderr")
+	rootCmd.PersistentFlags().BoolVar(&rest.TraceHTTP, "trace-http", false, "Log method, URL, status, latency and sizes of every HTTP request")
+	rootCmd.PersistentFlags().BoolVar(&rest.TraceBodies, "trace-http-bodies", false, "Log request and response bodies with --trace-http")
+	rootCmd.PersistentFlags().StringVar(&rest.TraceFile, "trace-http-file", "", "Write traced requests into HAR file (implies --trace-http)")
+	rootCmd.PersistentFlags().StringVar(&rest.HTTPFlags.Proxy, "proxy", "", "HTTP(S) proxy URL (default \"http.proxy\" from config or HTTPS_PROXY environment variable)")
+	rootCmd.PersistentFlags().StringVar(&rest.HTTPFlags.CABundle, "ca-bundle", "", "PEM file with additional trusted CA certificates")
+	rootCmd.PersistentFlags().StringVar(&rest.HTTPFlags.ClientCert, "client-cert", "", "PEM client certificate for mutual TLS")
+	rootCmd.PersistentFlags().StringVar(&rest.HTTPFlags.ClientKey, "client-key", "", "PEM private key of client certificate")
+	rootCmd.PersistentFlags().BoolVar(&rest.HTTPFlags.InsecureSkipVerify, "insecure-skip-verify", false, "Skip verification of server certificate (only with custom base_url)")
+	rootCmd.PersistentFlags().Float64Var(&rest.HTTPFlags.RateLimit, "rate-limit", 0, "Max HTTP requests per second (default \"http.rate_limit\" from config or unlimited, negative overrides config with unlimited)")
+	rootCmd.PersistentFlags().IntVar(&rest.HTTPFlags.MaxConcurrent, "max-concurrent", 0, "Max concurrent HTTP requests (default \"http.max_concurrent\" from config or unlimited, negative overrides config with unlimited)")
 	rootCmd.PersistentFlags().StringVar(&pkgconf.ConfigPathParam, "config", "", "Path to config")
+	rootCmd.PersistentFlags().StringVar(&output.Selected, "output", string(output.Table), "Output format: table, json, yaml, csv, markdown or template")
+	rootCmd.PersistentFlags().StringVar(&output.TemplateText, "template", "", "Go template applied to result with JSON field names, e.g. '{{range .}}{{.name}} {{end}}'")
+	rootCmd.PersistentFlags().BoolVar(&output.IsJSON, "json", false, "Print output in JSON format (same as --output json)")
 
 	rootCmd.AddCommand(
-		config.NewInitCmd(),
+		config.NewInitCmd(application),
 
-		file.NewPushCmd(),
-		file.NewDownloadCommand(),
-		file.NewDeleteFileCmd(),
+		file.NewPushCmd(application),
+		file.NewDownloadCommand(application),
+		file.NewDeleteFileCmd(application),
+		file.NewValidateCmd(application),
+		file.NewCheckCmd(application),
+		file.NewPseudoCmd(application),
+		file.NewCoverageCmd(application),
 
-		segment.NewAddKeyCommand(),
-		segment.NewUpdateSegmentCommand(),
-		segment.NewDeleteSegmentCommand(),
-		segment.NewValueKeyCommand(),
+		segment.NewAddKeyCommand(application),
+		segment.NewUpdateSegmentCommand(application),
+		segment.NewDeleteSegmentCommand(application),
+		segment.NewValueKeyCommand(application),
+		segment.NewSegmentsCommand(application),
+		segment.NewExtractCommand(application),
+		segment.NewSearchCommand(application),
+		segment.NewWorkflowCommand(application),
 
 		info.NewCmdVersion(),
-		info.NewLsCommand(),
-		info.NewStatusCommand(),
-		info.NewScoreCommand(),
+		info.NewLsCommand(application),
+		info.NewStatusCommand(application),
+		info.NewScoreCommand(application),
+		info.NewWaitCommand(application),
+
+		server.NewMockServerCommand(),
 	)
+	return rootCmd
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main()
+func Execute() {
+	if err := NewRootCmd().Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 }
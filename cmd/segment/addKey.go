@@ -2,6 +2,7 @@ package segment
 
 import (
 	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/qordobacode/cli-v2/pkg/types"
 
@@ -16,47 +17,60 @@ var (
 )
 
 // NewAddKeyCommand function
-func NewAddKeyCommand() *cobra.Command {
+func NewAddKeyCommand(application *app.App) *cobra.Command {
 	addKeyCmd := &cobra.Command{
 		Annotations: map[string]string{"group": "segment"},
 		Use:         "add-key",
 		Short:       "Add segments into file",
-		Example:     `qor add-key file_name.doc --version v1 --key "/go_nav_menu" --value "text text text" --ref "Main nav text"`,
-		PreRunE:     preValidateAddKeyParameters,
-		Run:         addKey,
+		Example: `qor add-key file_name.doc --version v1 --key "/go_nav_menu" --value "text text text" --ref "Main nav text"
+qor add-key file_name.doc --from keys.csv`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preValidateAddKeyParameters(application, cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) { addKey(application, cmd, args) },
 	}
 	addKeyCmd.Flags().StringVarP(&addKeyVersion, "version", "v", "", "file version")
 	addKeyCmd.Flags().StringVarP(&addKeyKey, "key", "k", "", "key to add")
 	addKeyCmd.Flags().StringVar(&addKeyValue, "value", "", "value to add")
 	addKeyCmd.Flags().StringVarP(&addKeyRef, "ref", "r", "", "")
+	addKeyCmd.Flags().StringVar(&keysFrom, "from", "", "add keys from .csv (key,value,reference) or .json file")
+	addKeySeparatorFlag(addKeyCmd)
 
 	return addKeyCmd
 }
 
-func preValidateAddKeyParameters(cmd *cobra.Command, args []string) error {
+func preValidateAddKeyParameters(application *app.App, cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("filename is mandatory")
 	}
+	if keysFrom != "" {
+		startLocalServices(application)(cmd, args)
+		return nil
+	}
 	if addKeyKey == "" {
 		return fmt.Errorf("flag 'key' is mandatory")
 	}
 	if addKeyValue == "" {
 		return fmt.Errorf("flag 'value' is mandatory")
 	}
-	startLocalServices(cmd, args)
+	startLocalServices(application)(cmd, args)
 	return nil
 }
 
-func addKey(cmd *cobra.Command, args []string) {
+func addKey(application *app.App, cmd *cobra.Command, args []string) {
 	log.Debugf("addKey called")
-	if appConfig == nil {
+	if application.Config == nil {
 		log.Errorf("error occurred on configuration load")
 		return
 	}
+	if keysFrom != "" {
+		printKeyResults(application.SegmentService.AddKeys(args[0], addKeyVersion, readKeysFrom(keysFrom)))
+		return
+	}
 	keyAddRequest := &types.KeyAddRequest{
 		Key:       addKeyKey,
 		Source:    addKeyValue,
 		Reference: addKeyRef,
 	}
-	segmentService.AddKey(args[0], addKeyVersion, keyAddRequest)
+	application.SegmentService.AddKey(args[0], addKeyVersion, keyAddRequest)
 }
@@ -0,0 +1,130 @@
+package segment
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	keysFrom          string
+	keyResultsHeaders = []string{"KEY", "RESULT"}
+)
+
+// keyOperation is a single line of `--from` file
+type keyOperation struct {
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Reference string `json:"reference"`
+}
+
+// readKeyOperations reads keys from `.csv` (columns `key,value,reference`, header is optional) or `.json`
+// (array of `{"key": "", "value": "", "reference": ""}` objects) file
+func readKeyOperations(path string) ([]*types.KeyAddRequest, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var operations []keyOperation
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, &operations)
+	case ".csv":
+		operations, err = parseKeysCSV(string(content))
+	default:
+		return nil, fmt.Errorf("unsupported file format of %s. Use .csv or .json file", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	requests := make([]*types.KeyAddRequest, 0, len(operations))
+	for i, operation := range operations {
+		if operation.Key == "" {
+			return nil, fmt.Errorf("key is empty in record #%d", i+1)
+		}
+		requests = append(requests, &types.KeyAddRequest{
+			Key:       operation.Key,
+			Source:    operation.Value,
+			Reference: operation.Reference,
+		})
+	}
+	return requests, nil
+}
+
+func parseKeysCSV(content string) ([]keyOperation, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{"key": 0, "value": 1, "reference": 2}
+	if len(records) > 0 && isKeysHeader(records[0]) {
+		columns = make(map[string]int)
+		for i, name := range records[0] {
+			columns[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+		records = records[1:]
+	}
+	column := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+	operations := make([]keyOperation, 0, len(records))
+	for _, record := range records {
+		operations = append(operations, keyOperation{
+			Key:       strings.TrimSpace(column(record, "key")),
+			Value:     column(record, "value"),
+			Reference: column(record, "reference"),
+		})
+	}
+	return operations, nil
+}
+
+func isKeysHeader(record []string) bool {
+	for _, name := range record {
+		if strings.EqualFold(strings.TrimSpace(name), "key") {
+			return true
+		}
+	}
+	return false
+}
+
+func readKeysFrom(path string) []*types.KeyAddRequest {
+	requests, err := readKeyOperations(path)
+	if err != nil {
+		log.Errorf("can't read keys from %s: %v", path, err)
+		os.Exit(1)
+	}
+	return requests
+}
+
+// printKeyResults renders result of every key and exits with non-zero code if some of them failed
+func printKeyResults(results []*types.KeyResult) {
+	data := make([][]string, 0, len(results))
+	failed := 0
+	for _, result := range results {
+		status := "ok"
+		if result.Error != "" {
+			status = result.Error
+			failed++
+		}
+		data = append(data, []string{result.Key, status})
+	}
+	if err := output.Print(&output.Result{Header: keyResultsHeaders, Rows: data, Value: results}); err != nil {
+		log.Errorf("error occurred on output: %v", err)
+	}
+	log.Infof("%d succeeded, %d failed", len(results)-failed, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
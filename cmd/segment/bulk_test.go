@@ -0,0 +1,36 @@
+package segment
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadKeyOperations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keys")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"header.csv": "reference,key,value\nnav,/menu,\"Menu, main\"\n,/title,Title\n",
+		"plain.csv":  "/menu,\"Menu, main\",nav\n/title,Title\n",
+		"keys.json":  `[{"key": "/menu", "value": "Menu, main", "reference": "nav"}, {"key": "/title", "value": "Title"}]`,
+	}
+	expected := []*types.KeyAddRequest{
+		{Key: "/menu", Source: "Menu, main", Reference: "nav"},
+		{Key: "/title", Source: "Title"},
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+		requests, err := readKeyOperations(path)
+		assert.Nil(t, err, name)
+		assert.Equal(t, expected, requests, name)
+	}
+	path := filepath.Join(dir, "empty-key.csv")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("/menu,Menu\n,Title\n"), 0644))
+	_, err = readKeyOperations(path)
+	assert.EqualError(t, err, "key is empty in record #2")
+}
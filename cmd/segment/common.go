@@ -1,52 +1,25 @@
 package segment
 
 import (
-	"github.com/qordobacode/cli-v2/pkg"
-	"github.com/qordobacode/cli-v2/pkg/config"
-	"github.com/qordobacode/cli-v2/pkg/file"
-	"github.com/qordobacode/cli-v2/pkg/general"
-	"github.com/qordobacode/cli-v2/pkg/rest"
-	"github.com/qordobacode/cli-v2/pkg/segments"
-	"github.com/qordobacode/cli-v2/pkg/types"
-	"github.com/qordobacode/cli-v2/pkg/workspace"
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/spf13/cobra"
-	"os"
 )
 
-var (
-	appConfig            *types.Config
-	local                *general.Local
-	configurationService = config.ConfigurationService{
-		Local: local,
-	}
-	qordobaClient    pkg.QordobaClient
-	workspaceService pkg.WorkspaceService
-	fileService      pkg.FileService
-	segmentService   pkg.SegmentService
-)
+// serverKeySeparator separates levels of keys stored on server
+const serverKeySeparator = "/"
 
-func startLocalServices(cmd *cobra.Command, args []string) {
-	var err error
-	appConfig, err = configurationService.LoadConfig()
-	if err != nil {
-		os.Exit(1)
-	}
-	qordobaClient = rest.NewRestClient(appConfig)
-	workspaceService = &workspace.Service{
-		Config:        appConfig,
-		QordobaClient: qordobaClient,
-		Local:         local,
-	}
-	fileService = &file.Service{
-		Config:           appConfig,
-		WorkspaceService: workspaceService,
-		Local:            local,
-		QordobaClient:    qordobaClient,
-	}
-	segmentService = &segments.SegmentService{
-		QordobaClient:    qordobaClient,
-		WorkspaceService: workspaceService,
-		Config:           appConfig,
-		FileService:      fileService,
+var keySeparator string
+
+// startLocalServices returns PreRun function, which initializes application services required by segment commands
+func startLocalServices(application *app.App) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		application.MustInit()
+		if keySeparator != "" {
+			application.Config.Segments.KeySeparator = keySeparator
+		}
 	}
 }
+
+func addKeySeparatorFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&keySeparator, "key-separator", "", "separator of key levels, e.g. \".\" for \"menu.file.open\" keys (default segments.key_separator from config or \"/\")")
+}
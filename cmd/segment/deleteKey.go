@@ -1,6 +1,7 @@
 package segment
 
 import (
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/spf13/cobra"
 )
 
@@ -11,21 +12,34 @@ var (
 )
 
 // NewDeleteSegmentCommand function add `delete-key` command
-func NewDeleteSegmentCommand() *cobra.Command {
+func NewDeleteSegmentCommand(application *app.App) *cobra.Command {
 	deleteKeyCmd := &cobra.Command{
 		Annotations: map[string]string{"group": "segment"},
 		Use:         "delete-key",
 		Short:       "Delete segment",
-		Example:     `qor delete-key file_name.doc --version v1 --key "/go_nav_menu"`,
-		PreRun:      startLocalServices,
-		Run:         deleteSegment,
+		Example: `qor delete-key file_name.doc --version v1 --key "/go_nav_menu"
+qor delete-key file_name.doc --from keys.csv`,
+		Args:   cobra.ExactArgs(1),
+		PreRun: startLocalServices(application),
+		Run:    func(cmd *cobra.Command, args []string) { deleteSegment(application, cmd, args) },
 	}
 
 	deleteKeyCmd.Flags().StringVarP(&deleteKeyVersion, "version", "v", "", "file version where update segment")
 	deleteKeyCmd.Flags().StringVarP(&deleteKeyKey, "key", "k", "", "key to delete")
+	deleteKeyCmd.Flags().StringVar(&keysFrom, "from", "", "delete keys listed in .csv (key column) or .json file")
+	addKeySeparatorFlag(deleteKeyCmd)
 	return deleteKeyCmd
 }
 
-func deleteSegment(cmd *cobra.Command, args []string) {
-	segmentService.DeleteKey(args[0], deleteKeyVersion, deleteKeyKey)
+func deleteSegment(application *app.App, cmd *cobra.Command, args []string) {
+	if keysFrom != "" {
+		requests := readKeysFrom(keysFrom)
+		keys := make([]string, 0, len(requests))
+		for _, request := range requests {
+			keys = append(keys, request.Key)
+		}
+		printKeyResults(application.SegmentService.DeleteKeys(args[0], deleteKeyVersion, keys))
+		return
+	}
+	application.SegmentService.DeleteKey(args[0], deleteKeyVersion, deleteKeyKey)
 }
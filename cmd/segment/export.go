@@ -0,0 +1,235 @@
+package segment
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/general/date"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+const (
+	exportJSON  = "json"
+	exportCSV   = "csv"
+	exportXLIFF = "xliff"
+)
+
+var (
+	exportVersion  string
+	exportAudience string
+	exportWorkflow string
+	exportFormat   string
+	exportOutput   string
+	exportHeaders  = []string{"key", "source", "target", "reference", "last_saved", "workflow"}
+)
+
+// exportedSegment is a segment representation in JSON and CSV exports
+type exportedSegment struct {
+	Key       string `json:"key"`
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Reference string `json:"reference"`
+	LastSaved string `json:"last_saved"`
+	Workflow  string `json:"workflow"`
+}
+
+// xliff is a minimal XLIFF 1.2 document
+type xliff struct {
+	XMLName xml.Name  `xml:"urn:oasis:names:tc:xliff:document:1.2 xliff"`
+	Version string    `xml:"version,attr"`
+	File    xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original       string           `xml:"original,attr"`
+	SourceLanguage string           `xml:"source-language,attr"`
+	TargetLanguage string           `xml:"target-language,attr"`
+	Datatype       string           `xml:"datatype,attr"`
+	Units          []xliffTransUnit `xml:"body>trans-unit"`
+}
+
+type xliffTransUnit struct {
+	ID      string `xml:"id,attr"`
+	Resname string `xml:"resname,attr"`
+	Source  string `xml:"source"`
+	Target  string `xml:"target"`
+	Note    string `xml:"note,omitempty"`
+}
+
+// NewSegmentsCommand creates `segments` command with segment dump subcommands
+func NewSegmentsCommand(application *app.App) *cobra.Command {
+	segmentsCmd := &cobra.Command{
+		Annotations: map[string]string{"group": "segment"},
+		Use:         "segments",
+		Short:       "Work with all segments of a file",
+	}
+	exportCmd := &cobra.Command{
+		Use:   "export <file>",
+		Short: "Export all segments of a file",
+		Long:  "Pages through all segments of a file for a persona and outputs key, source, target, reference and last saved date as JSON, CSV or XLIFF",
+		Example: `qor segments export file_name.json --version v1 -a fr-fr --format xliff --output-file file_name.fr-fr.xliff
+qor segments export file_name.json --workflow Translation --format csv`,
+		Args:   cobra.ExactArgs(1),
+		PreRun: startLocalServices(application),
+		Run:    func(cmd *cobra.Command, args []string) { exportSegments(application, cmd, args) },
+	}
+	exportCmd.Flags().StringVarP(&exportVersion, "version", "v", "", "file version")
+	exportCmd.Flags().StringVarP(&exportAudience, "audience", "a", "", "target persona code (default persona where the file was found)")
+	exportCmd.Flags().StringVar(&exportWorkflow, "workflow", "", "export only segments of specified workflow step")
+	exportCmd.Flags().StringVar(&exportFormat, "format", exportJSON, "output format: json, csv or xliff")
+	exportCmd.Flags().StringVarP(&exportOutput, "output-file", "o", "", "file to write export into (default stdout)")
+	segmentsCmd.AddCommand(exportCmd)
+	return segmentsCmd
+}
+
+func exportSegments(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
+		log.Errorf("error occurred on configuration load")
+		return
+	}
+	if exportFormat != exportJSON && exportFormat != exportCSV && exportFormat != exportXLIFF {
+		log.Errorf("Invalid format '%s'; please provide one of: json, csv, xliff", exportFormat)
+		os.Exit(1)
+	}
+	workspace, err := application.WorkspaceService.LoadWorkspace()
+	if err != nil || workspace == nil {
+		os.Exit(1)
+	}
+	file, personaID := application.FileService.FindFile(args[0], exportVersion, false)
+	if file == nil {
+		os.Exit(1)
+	}
+	persona, ok := exportPersona(&workspace.Workspace, personaID)
+	if !ok {
+		os.Exit(1)
+	}
+	segmentList, err := application.SegmentService.ListSegments(file, persona.ID)
+	if err != nil {
+		log.Errorf("error occurred on segments load: %v", err)
+		os.Exit(1)
+	}
+	segmentList = filterWorkflow(segmentList, exportWorkflow)
+	content, err := formatSegments(segmentList, file, workspace.Workspace.SourcePersona.Code, persona.Code)
+	if err != nil {
+		log.Errorf("error occurred on segments export: %v", err)
+		os.Exit(1)
+	}
+	if exportOutput == "" {
+		if _, err = fmt.Fprintf(output.Stdout, "%s\n", content); err != nil {
+			log.Errorf("error occurred on output: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if err = ioutil.WriteFile(exportOutput, content, 0644); err != nil {
+		log.Errorf("error occurred on writing file: %v", err)
+		os.Exit(1)
+	}
+	log.Infof("%d segments were exported to %s", len(segmentList), exportOutput)
+}
+
+func exportPersona(workspace *types.Workspace, personaID int) (types.Person, bool) {
+	targetCodes := make([]string, 0, len(workspace.TargetPersonas))
+	for _, persona := range workspace.TargetPersonas {
+		if (exportAudience == "" && persona.ID == personaID) || persona.Code == exportAudience {
+			return persona, true
+		}
+		targetCodes = append(targetCodes, "`"+persona.Code+"`")
+	}
+	if exportAudience == "" {
+		log.Errorf("Target persona of the file was not found. Please provide one of %s with `-a` flag", strings.Join(targetCodes, ", "))
+		return types.Person{}, false
+	}
+	log.Errorf("`%s` does not match one of available project target languages: %s", exportAudience, strings.Join(targetCodes, ", "))
+	return types.Person{}, false
+}
+
+func filterWorkflow(segmentList []types.Segment, workflow string) []types.Segment {
+	if workflow == "" {
+		return segmentList
+	}
+	filtered := make([]types.Segment, 0, len(segmentList))
+	for _, segment := range segmentList {
+		if strings.EqualFold(segment.Workflow, workflow) {
+			filtered = append(filtered, segment)
+		}
+	}
+	return filtered
+}
+
+func formatSegments(segmentList []types.Segment, file *types.File, sourceCode, targetCode string) ([]byte, error) {
+	exported := make([]exportedSegment, 0, len(segmentList))
+	for _, segment := range segmentList {
+		lastSaved := ""
+		if segment.LastSaved > 0 {
+			lastSaved = date.GetDateFromTimestamp(int64(segment.LastSaved))
+		}
+		exported = append(exported, exportedSegment{
+			Key:       segment.StringKey,
+			Source:    segment.Segment,
+			Target:    segment.Target,
+			Reference: segment.Reference,
+			LastSaved: lastSaved,
+			Workflow:  segment.Workflow,
+		})
+	}
+	switch exportFormat {
+	case exportCSV:
+		return segmentsCSV(exported)
+	case exportXLIFF:
+		return segmentsXLIFF(exported, file, sourceCode, targetCode)
+	}
+	return json.MarshalIndent(exported, "", "  ")
+}
+
+func segmentsCSV(exported []exportedSegment) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(exportHeaders); err != nil {
+		return nil, err
+	}
+	for _, segment := range exported {
+		record := []string{segment.Key, segment.Source, segment.Target, segment.Reference, segment.LastSaved, segment.Workflow}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+func segmentsXLIFF(exported []exportedSegment, file *types.File, sourceCode, targetCode string) ([]byte, error) {
+	document := xliff{
+		Version: "1.2",
+		File: xliffFile{
+			Original:       file.Filename,
+			SourceLanguage: sourceCode,
+			TargetLanguage: targetCode,
+			Datatype:       "plaintext",
+			Units:          make([]xliffTransUnit, 0, len(exported)),
+		},
+	}
+	for _, segment := range exported {
+		document.File.Units = append(document.File.Units, xliffTransUnit{
+			ID:      segment.Key,
+			Resname: segment.Key,
+			Source:  segment.Source,
+			Target:  segment.Target,
+			Note:    segment.Reference,
+		})
+	}
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("%s%s\n", xml.Header, body)), nil
+}
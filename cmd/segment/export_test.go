@@ -0,0 +1,44 @@
+package segment
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var exportSegmentList = []types.Segment{
+	{StringKey: "/title", Segment: "Hello, <b>world</b>", Target: "Bonjour", Reference: "Main page", Workflow: "Translation"},
+	{StringKey: "/menu", Segment: "Menu", Workflow: "Review"},
+}
+
+func TestFormatSegments(t *testing.T) {
+	file := &types.File{Filename: "en.json"}
+	exportFormat = exportCSV
+	content, err := formatSegments(exportSegmentList, file, "en-us", "fr-fr")
+	assert.Nil(t, err)
+	assert.Equal(t, "key,source,target,reference,last_saved,workflow\n"+
+		"/title,\"Hello, <b>world</b>\",Bonjour,Main page,,Translation\n"+
+		"/menu,Menu,,,,Review\n", string(content))
+
+	exportFormat = exportXLIFF
+	content, err = formatSegments(filterWorkflow(exportSegmentList, "translation"), file, "en-us", "fr-fr")
+	assert.Nil(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="en.json" source-language="en-us" target-language="fr-fr" datatype="plaintext">
+    <body>
+      <trans-unit id="/title" resname="/title">
+        <source>Hello, &lt;b&gt;world&lt;/b&gt;</source>
+        <target>Bonjour</target>
+        <note>Main page</note>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>
+`, string(content))
+
+	exportFormat = exportJSON
+	content, err = formatSegments(exportSegmentList[1:], file, "en-us", "fr-fr")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"key": "/menu", "source": "Menu", "target": "", "reference": "", "last_saved": "", "workflow": "Review"}]`, string(content))
+}
@@ -0,0 +1,246 @@
+package segment
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/extract"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/resource"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	extractPatterns []string
+	extractResource string
+	extractVersion  string
+	extractPushFile string
+	isExtractPush   bool
+	isExtractDryRun bool
+	extractHeaders  = []string{"KEY", "REFERENCE"}
+)
+
+// extractResult is a summary of `extract` command
+type extractResult struct {
+	NewKeys    []extract.Reference `json:"new_keys"`
+	UnusedKeys []string            `json:"unused_keys"`
+}
+
+// NewExtractCommand creates `extract` command
+func NewExtractCommand(application *app.App) *cobra.Command {
+	extractCmd := &cobra.Command{
+		Annotations: map[string]string{"group": "segment"},
+		Use:         "extract [paths to scan]",
+		Short:       "Extract keys from application source code",
+		Long: "Scans source code (Go i18n calls, JS/TS `t('key')`, Android `R.string.key` and regexes from `extract.patterns` config) " +
+			"and adds new keys to the source resource file and/or Qordoba. Keys of resource file, which are not used in code, are reported",
+		Example: `"qor extract src --resource i18n/en.json", "qor extract --push --push-file en.json --dry-run"`,
+		PreRun:  startLocalServices(application),
+		Run:     func(cmd *cobra.Command, args []string) { extractKeys(application, cmd, args) },
+	}
+	extractCmd.Flags().StringArrayVar(&extractPatterns, "pattern", nil, "Regex with a group capturing a key (might be used several times)")
+	extractCmd.Flags().StringVar(&extractResource, "resource", "", "Source resource file to add new keys into (default `extract.resource` from config)")
+	extractCmd.Flags().BoolVar(&isExtractPush, "push", false, "Add new keys to Qordoba file")
+	extractCmd.Flags().StringVar(&extractPushFile, "push-file", "", "Qordoba file name to add keys into (default name of resource file)")
+	extractCmd.Flags().StringVarP(&extractVersion, "version", "v", "", "Qordoba file version")
+	extractCmd.Flags().BoolVar(&isExtractDryRun, "dry-run", false, "Only report new and unused keys")
+	addKeySeparatorFlag(extractCmd)
+	return extractCmd
+}
+
+func extractKeys(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
+		log.Errorf("error occurred on configuration load")
+		return
+	}
+	patterns, ok := extractPatternList(application)
+	if !ok {
+		os.Exit(1)
+	}
+	paths := args
+	if len(paths) == 0 {
+		paths = application.Config.Extract.Sources
+	}
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	references, err := extract.Scan(paths, patterns)
+	if err != nil {
+		log.Errorf("error occurred on source code scan: %v", err)
+		os.Exit(1)
+	}
+	resourcePath := extractResource
+	if resourcePath == "" {
+		resourcePath = application.Config.Extract.Resource
+	}
+	if resourcePath == "" && !isExtractPush {
+		log.Errorf("Please provide source resource file with `--resource` flag or `extract.resource` config, or use `--push` flag")
+		os.Exit(1)
+	}
+	pushFile := ""
+	if isExtractPush {
+		pushFile = extractPushFileName(resourcePath)
+	}
+	var resourceFile *resource.File
+	if resourcePath != "" {
+		resourceFile, err = readResourceFile(application, resourcePath)
+		if err != nil {
+			log.Errorf("can't read resource file %s: %v", resourcePath, err)
+			os.Exit(1)
+		}
+	} else {
+		resourceFile = remoteKeys(application, pushFile)
+	}
+	result := compareWithResource(references, resourceFile)
+	printExtractResult(result)
+	if isExtractDryRun || len(result.NewKeys) == 0 {
+		return
+	}
+	if resourcePath != "" {
+		addKeysToResource(application, resourceFile, result.NewKeys)
+	}
+	if isExtractPush {
+		pushExtractedKeys(application, pushFile, result.NewKeys)
+	}
+}
+
+func extractPatternList(application *app.App) ([]extract.Pattern, bool) {
+	patterns := append([]extract.Pattern{}, extract.DefaultPatterns...)
+	for _, expression := range append(application.Config.Extract.Patterns, extractPatterns...) {
+		pattern, err := extract.NewPattern(expression)
+		if err != nil {
+			log.Errorf("invalid extract pattern: %v", err)
+			return nil, false
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, true
+}
+
+func readResourceFile(application *app.App, path string) (*resource.File, error) {
+	content, err := application.Local.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	return resource.Parse(path, content)
+}
+
+// remoteKeys loads keys of Qordoba file to find new keys in, when there is no resource file.
+// Server's `/menu/open` keys are converted into configured key separator levels
+func remoteKeys(application *app.App, fileName string) *resource.File {
+	file, personaID := application.FileService.FindFile(fileName, extractVersion, false)
+	if file == nil {
+		os.Exit(1)
+	}
+	segmentList, err := application.SegmentService.ListSegments(file, personaID)
+	if err != nil {
+		log.Errorf("error occurred on segments load: %v", err)
+		os.Exit(1)
+	}
+	separator := application.Config.Segments.KeySeparator
+	if separator == "" {
+		separator = serverKeySeparator
+	}
+	remoteFile := &resource.File{Path: fileName, Entries: make([]resource.Entry, 0, len(segmentList))}
+	loaded := make(map[string]bool)
+	for _, segment := range segmentList {
+		key := strings.ReplaceAll(strings.TrimPrefix(segment.StringKey, serverKeySeparator), serverKeySeparator, separator)
+		if loaded[key] {
+			continue
+		}
+		loaded[key] = true
+		remoteFile.Entries = append(remoteFile.Entries, resource.Entry{Key: key, Value: segment.Segment})
+	}
+	return remoteFile
+}
+
+// compareWithResource returns first references of keys absent in resource file and resource keys not used in code.
+// Plural and array keys (`name.one`, `name.0`) are used if their `name` is referenced
+func compareWithResource(references []extract.Reference, resourceFile *resource.File) *extractResult {
+	result := &extractResult{NewKeys: make([]extract.Reference, 0), UnusedKeys: make([]string, 0)}
+	values := resourceFile.Values()
+	referenced := make(map[string]bool)
+	for _, reference := range references {
+		if _, ok := values[reference.Key]; !ok && !referenced[reference.Key] {
+			result.NewKeys = append(result.NewKeys, reference)
+		}
+		referenced[reference.Key] = true
+	}
+	for _, entry := range resourceFile.Entries {
+		key := entry.Key
+		if referenced[key] {
+			continue
+		}
+		if index := strings.LastIndex(key, "."); index > 0 && referenced[key[:index]] {
+			continue
+		}
+		result.UnusedKeys = append(result.UnusedKeys, key)
+	}
+	return result
+}
+
+func addKeysToResource(application *app.App, resourceFile *resource.File, references []extract.Reference) {
+	entries := make([]resource.Entry, 0, len(references))
+	for _, reference := range references {
+		entries = append(entries, resource.Entry{Key: reference.Key, Value: reference.Key})
+	}
+	content, err := resourceFile.Add(entries)
+	if err != nil {
+		log.Errorf("can't add keys to %s: %v", resourceFile.Path, err)
+		os.Exit(1)
+	}
+	application.Local.Write(resourceFile.Path, content)
+	log.Infof("%d keys were added to %s", len(entries), resourceFile.Path)
+}
+
+func extractPushFileName(resourcePath string) string {
+	fileName := extractPushFile
+	if fileName == "" && resourcePath != "" {
+		fileName = filepath.Base(resourcePath)
+	}
+	if fileName == "" {
+		log.Errorf("Please provide Qordoba file name with `--push-file` flag")
+		os.Exit(1)
+	}
+	return fileName
+}
+
+// pushExtractedKeys adds keys to Qordoba file and exits with non-zero code if some of them failed
+func pushExtractedKeys(application *app.App, fileName string, references []extract.Reference) {
+	requests := make([]*types.KeyAddRequest, 0, len(references))
+	for _, reference := range references {
+		requests = append(requests, &types.KeyAddRequest{
+			Key:       reference.Key,
+			Source:    reference.Key,
+			Reference: reference.String(),
+		})
+	}
+	failed := 0
+	for _, result := range application.SegmentService.AddKeys(fileName, extractVersion, requests) {
+		if result.Error != "" {
+			log.Errorf("Problem to add key '%s'. %s", result.Key, result.Error)
+			failed++
+		}
+	}
+	log.Infof("%d keys were added to %s, %d failed", len(requests)-failed, fileName, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func printExtractResult(result *extractResult) {
+	data := make([][]string, 0, len(result.NewKeys))
+	for _, reference := range result.NewKeys {
+		data = append(data, []string{reference.Key, reference.String()})
+	}
+	if err := output.Print(&output.Result{Header: extractHeaders, Rows: data, Value: result}); err != nil {
+		log.Errorf("error occurred on output: %v", err)
+	}
+	log.Infof("%d new keys found", len(result.NewKeys))
+	if len(result.UnusedKeys) > 0 {
+		log.Infof("%d keys are not used in code:\n  %s", len(result.UnusedKeys), strings.Join(result.UnusedKeys, "\n  "))
+	}
+}
@@ -0,0 +1,105 @@
+package segment
+
+import (
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+var (
+	searchAudiences    []string
+	isSearchAll        bool
+	isSearchKeyPattern bool
+	searchHeaders      = []string{"FILE NAME", "#VERSION", "PERSONA", "KEY", "SOURCE", "TARGET"}
+)
+
+// NewSearchCommand creates `search` command
+func NewSearchCommand(application *app.App) *cobra.Command {
+	searchCmd := &cobra.Command{
+		Annotations: map[string]string{"group": "segment"},
+		Use:         "search <text|key-regex>",
+		Short:       "Search segments across all files of workspace",
+		Long:        "Searches segments by text (or keys by regular expression with `--key` flag) in every file of workspace and prints matched keys with source and target values",
+		Example: `qor search "Save file"
+qor search --key "^/menu/" --audience fr-fr,de-de
+qor search "Open" --all-audiences --json`,
+		Args:   cobra.ExactArgs(1),
+		PreRun: startLocalServices(application),
+		Run:    func(cmd *cobra.Command, args []string) { searchSegments(application, cmd, args) },
+	}
+	searchCmd.Flags().BoolVar(&isSearchKeyPattern, "key", false, "treat argument as a regular expression matched against keys")
+	searchCmd.Flags().StringSliceVarP(&searchAudiences, "audience", "a", nil, "target persona codes to search in (default first target persona)")
+	searchCmd.Flags().BoolVar(&isSearchAll, "all-audiences", false, "search in all target personas")
+	return searchCmd
+}
+
+func searchSegments(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
+		log.Errorf("error occurred on configuration load")
+		return
+	}
+	workspace, err := application.WorkspaceService.LoadWorkspace()
+	if err != nil || workspace == nil {
+		os.Exit(1)
+	}
+	personas, err := searchPersonas(workspace.Workspace.TargetPersonas)
+	if err != nil {
+		log.Errorf("%v", err)
+		os.Exit(1)
+	}
+	matches, err := application.SegmentService.SearchSegments(args[0], isSearchKeyPattern, personas)
+	if err != nil {
+		log.Errorf("error occurred on segments search: %v", err)
+		os.Exit(1)
+	}
+	printSearchResult(matches)
+}
+
+// searchPersonas returns target personas listed in `--audience` flag, all of them with `--all-audiences` flag
+// or the first one by default
+func searchPersonas(targetPersonas []types.Person) ([]types.Person, error) {
+	if len(targetPersonas) == 0 {
+		return nil, fmt.Errorf("workspace has no target personas")
+	}
+	if isSearchAll {
+		return targetPersonas, nil
+	}
+	if len(searchAudiences) == 0 {
+		return targetPersonas[:1], nil
+	}
+	personas := make([]types.Person, 0, len(searchAudiences))
+	targetCodes := make([]string, 0, len(targetPersonas))
+	for _, persona := range targetPersonas {
+		targetCodes = append(targetCodes, "`"+persona.Code+"`")
+	}
+	for _, code := range searchAudiences {
+		found := false
+		for _, persona := range targetPersonas {
+			if persona.Code == code {
+				personas = append(personas, persona)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("`%s` does not match one of available project target languages: %s", code, strings.Join(targetCodes, ", "))
+		}
+	}
+	return personas, nil
+}
+
+func printSearchResult(matches []*types.SegmentMatch) {
+	data := make([][]string, 0, len(matches))
+	for _, match := range matches {
+		data = append(data, []string{match.File, match.Version, match.Persona, match.Key, match.Source, match.Target})
+	}
+	if err := output.Print(&output.Result{Header: searchHeaders, Rows: data, Value: matches}); err != nil {
+		log.Errorf("error occurred on output: %v", err)
+	}
+	log.Infof("%d segments found", len(matches))
+}
@@ -0,0 +1,29 @@
+package segment
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSearchPersonas(t *testing.T) {
+	targetPersonas := []types.Person{{ID: 3, Code: "fr-fr"}, {ID: 4, Code: "de-de"}}
+	personas, err := searchPersonas(targetPersonas)
+	assert.Nil(t, err)
+	assert.Equal(t, targetPersonas[:1], personas)
+
+	searchAudiences = []string{"de-de"}
+	personas, err = searchPersonas(targetPersonas)
+	assert.Nil(t, err)
+	assert.Equal(t, []types.Person{{ID: 4, Code: "de-de"}}, personas)
+
+	searchAudiences = []string{"es-es"}
+	_, err = searchPersonas(targetPersonas)
+	assert.EqualError(t, err, "`es-es` does not match one of available project target languages: `fr-fr`, `de-de`")
+
+	isSearchAll = true
+	personas, err = searchPersonas(targetPersonas)
+	assert.Nil(t, err)
+	assert.Equal(t, targetPersonas, personas)
+	isSearchAll, searchAudiences = false, nil
+}
@@ -16,6 +16,7 @@ package segment
 
 import (
 	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"github.com/spf13/cobra"
@@ -27,49 +28,71 @@ var (
 	updateKeyKey     string
 	updateKeyValue   string
 	updateKeyRef     string
+	updateAudiences  []string
+	isMoveToFirst    bool
 )
 
 // NewUpdateSegmentCommand function add `update-value` command
-func NewUpdateSegmentCommand() *cobra.Command {
+func NewUpdateSegmentCommand(application *app.App) *cobra.Command {
 	updateValueCmd := &cobra.Command{
 		Annotations: map[string]string{"group": "segment"},
 		Use:         "update-value",
 		Short:       "Update value by key",
-		Example:     `qor update-value file_name.doc --version v1 --key "/go_nav_menu" --value "text text text" --ref "Main nav text"`,
-		PreRunE:     preValidateUpdateKeyParameters,
-		Run:         updateValue,
+		Example: `qor update-value file_name.doc --version v1 --key "/go_nav_menu" --value "text text text" --ref "Main nav text"
+qor update-value file_name.doc --key "/go_nav_menu" --value "texte" --audience fr-fr --move-to-first-step
+qor update-value file_name.doc --from values.json`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preValidateUpdateKeyParameters(application, cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) { updateValue(application, cmd, args) },
 	}
 
 	updateValueCmd.Flags().StringVarP(&updateKeyVersion, "version", "v", "", "file version")
 	updateValueCmd.Flags().StringVarP(&updateKeyKey, "key", "k", "", "key to add")
 	updateValueCmd.Flags().StringVar(&updateKeyValue, "value", "", "value to add")
 	updateValueCmd.Flags().StringVarP(&updateKeyRef, "ref", "r", "", "")
+	updateValueCmd.Flags().StringVar(&keysFrom, "from", "", "update values from .csv (key,value) or .json file")
+	updateValueCmd.Flags().StringSliceVarP(&updateAudiences, "audience", "a", nil, "update translations of listed target persona codes instead of source text")
+	updateValueCmd.Flags().BoolVar(&isMoveToFirst, "move-to-first-step", false, "move updated segments to the first workflow step")
+	addKeySeparatorFlag(updateValueCmd)
 	return updateValueCmd
 }
 
-func preValidateUpdateKeyParameters(cmd *cobra.Command, args []string) error {
+func preValidateUpdateKeyParameters(application *app.App, cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("filename is mandatory")
 	}
+	if keysFrom != "" {
+		startLocalServices(application)(cmd, args)
+		return nil
+	}
 	if updateKeyKey == "" {
 		return fmt.Errorf("flag 'key' is mandatory")
 	}
 	if updateKeyValue == "" {
 		return fmt.Errorf("flag 'value' is mandatory")
 	}
-	startLocalServices(cmd, args)
+	startLocalServices(application)(cmd, args)
 	return nil
 }
 
-func updateValue(cmd *cobra.Command, args []string) {
-	if appConfig == nil {
+func updateValue(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
 		log.Errorf("error occurred on configuration load: ")
 		return
 	}
+	options := &types.ValueUpdateOptions{
+		Audiences:       updateAudiences,
+		MoveToFirstStep: isMoveToFirst,
+	}
+	if keysFrom != "" {
+		printKeyResults(application.SegmentService.UpdateKeys(args[0], updateKeyVersion, readKeysFrom(keysFrom), options))
+		return
+	}
 	keyAddRequest := &types.KeyAddRequest{
 		Key:       updateKeyKey,
 		Source:    updateKeyValue,
 		Reference: updateKeyRef,
 	}
-	segmentService.UpdateKey(args[0], updateKeyVersion, keyAddRequest)
+	application.SegmentService.UpdateKey(args[0], updateKeyVersion, keyAddRequest, options)
 }
@@ -1,101 +1,115 @@
 package segment
 
 import (
-	"encoding/json"
 	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/app"
 	"github.com/qordobacode/cli-v2/pkg/general/date"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/types"
 	"github.com/spf13/cobra"
+	"strings"
 )
 
 // updateValueCmd represents the updateValue command
 var (
 	valueKeyVersion string
 	valueKeyKey     string
-	IsJSON          bool
+	valueAudiences  []string
 	header          = []string{"FILE NAME", "#VERSION", "KEY", "#VALUE", "#REF", "#TIMESTAMP"}
 )
 
 // NewValueKeyCommand function add `value-key` command
-func NewValueKeyCommand() *cobra.Command {
+func NewValueKeyCommand(application *app.App) *cobra.Command {
 	valueKeyCmd := &cobra.Command{
 		Annotations: map[string]string{"group": "segment"},
 		Use:         `value-key`,
-		Example:     `qor value-key file_name.doc --version v1 --key "/go_nav_menu"`,
-		Short:       "Pull value by key",
-		PreRunE:     preValidateValueKeyParameters,
-		Run:         pullValueByKey,
+		Example: `qor value-key file_name.doc --version v1 --key "/go_nav_menu"
+qor value-key file_name.doc --key "/go_nav_menu" --audience fr-fr,de-de`,
+		Short: "Pull value by key",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preValidateValueKeyParameters(application, cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) { pullValueByKey(application, cmd, args) },
 	}
 
 	valueKeyCmd.Flags().StringVarP(&valueKeyVersion, "version", "v", "", "file version")
 	valueKeyCmd.Flags().StringVarP(&valueKeyKey, "key", "k", "", "key to get value")
-	valueKeyCmd.PersistentFlags().BoolVar(&IsJSON, "json", false, "Print output in JSON format")
+	valueKeyCmd.Flags().StringSliceVarP(&valueAudiences, "audience", "a", nil, "show translations of listed target persona codes")
+	addKeySeparatorFlag(valueKeyCmd)
 	return valueKeyCmd
 }
 
-func preValidateValueKeyParameters(cmd *cobra.Command, args []string) error {
+func preValidateValueKeyParameters(application *app.App, cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("filename is mandatory")
 	}
 	if valueKeyKey == "" {
 		return fmt.Errorf("flag 'key' is mandatory")
 	}
-	startLocalServices(cmd, args)
+	startLocalServices(application)(cmd, args)
 	return nil
 }
 
-func pullValueByKey(cmd *cobra.Command, args []string) {
-	segment, _ := segmentService.FindSegment(args[0], valueKeyVersion, valueKeyKey)
+func pullValueByKey(application *app.App, cmd *cobra.Command, args []string) {
+	var segment *types.Segment
+	var translations map[string]string
+	if len(valueAudiences) > 0 {
+		segment, translations = application.SegmentService.FindTranslations(args[0], valueKeyVersion, valueKeyKey, valueAudiences)
+	} else {
+		segment, _ = application.SegmentService.FindSegment(args[0], valueKeyVersion, valueKeyKey)
+	}
 	if segment == nil {
 		return
 	}
 	resultArray := make([]*valueInfo, 0)
 	formattedTimestamp := date.GetDateFromTimestamp(int64(segment.LastSaved))
 	resultArray = append(resultArray, &valueInfo{
-		Filename:    args[0],
-		FileVersion: valueKeyVersion,
-		Key:         valueKeyKey,
-		Value:       segment.SsText,
-		Reference:   segment.Reference,
-		Timestamp:   formattedTimestamp,
+		Filename:     args[0],
+		FileVersion:  valueKeyVersion,
+		Key:          valueKeyKey,
+		Value:        segment.SsText,
+		Translations: translations,
+		Reference:    segment.Reference,
+		Timestamp:    formattedTimestamp,
 	})
 	printProjectStatus2Stdin(resultArray)
 }
 
 type valueInfo struct {
-	Filename    string `json:"file_name"`
-	FileVersion string `json:"file_version"`
-	Key         string `json:"key"`
-	Value       string `json:"value"`
-	Reference   string `json:"reference"`
-	Timestamp   string `json:"timestamp"`
+	Filename     string            `json:"file_name"`
+	FileVersion  string            `json:"file_version"`
+	Key          string            `json:"key"`
+	Value        string            `json:"value"`
+	Translations map[string]string `json:"translations,omitempty"`
+	Reference    string            `json:"reference"`
+	Timestamp    string            `json:"timestamp"`
 }
 
 func printProjectStatus2Stdin(valueInfo []*valueInfo) {
-	if !IsJSON {
-		data := formatResponse2Array(valueInfo)
-		local.RenderTable2Stdin(header, data)
-		return
+	result := &output.Result{Header: valueHeader(), Rows: formatResponse2Array(valueInfo), Value: valueInfo}
+	if err := output.Print(result); err != nil {
+		log.Errorf("error occurred on output: %v", err)
 	}
-	bytes, err := json.MarshalIndent(valueInfo, "", "  ")
-	if err != nil {
-		log.Errorf("error occurred on marshalling with JSON: %v", err)
-		return
+}
+
+// valueHeader returns table header with a column for each requested translation after the source value
+func valueHeader() []string {
+	columns := append([]string{}, header[:4]...)
+	for _, audience := range valueAudiences {
+		columns = append(columns, "#"+strings.ToUpper(audience))
 	}
-	log.Infof("%v", string(bytes))
+	return append(columns, header[4:]...)
 }
 
 func formatResponse2Array(response []*valueInfo) [][]string {
 	data := make([][]string, 0, len(response))
 	for _, valInfo := range response {
-		row := make([]string, len(header))
-		header = []string{"FILE NAME", "#VERSION", "KEY", "#VALUE", "#REF", "#TIMESTAMP"}
-		row[0] = valInfo.Filename
-		row[1] = valInfo.FileVersion
-		row[2] = valInfo.Key
-		row[3] = valInfo.Value
-		row[4] = valInfo.Reference
-		row[5] = valInfo.Timestamp
+		row := []string{valInfo.Filename, valInfo.FileVersion, valInfo.Key, valInfo.Value}
+		for _, audience := range valueAudiences {
+			row = append(row, valInfo.Translations[audience])
+		}
+		row = append(row, valInfo.Reference, valInfo.Timestamp)
 		data = append(data, row)
 	}
 	return data
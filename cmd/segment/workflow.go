@@ -0,0 +1,95 @@
+package segment
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/app"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strconv"
+)
+
+var (
+	moveVersion     string
+	moveAudience    string
+	moveKeys        []string
+	moveStep        string
+	isMoveFirstStep bool
+	workflowHeaders = []string{"ID", "NAME", "ORDER", "COMPLETE"}
+)
+
+// NewWorkflowCommand creates `workflow` command with workflow steps subcommands
+func NewWorkflowCommand(application *app.App) *cobra.Command {
+	workflowCmd := &cobra.Command{
+		Annotations: map[string]string{"group": "segment"},
+		Use:         "workflow",
+		Short:       "Work with workflow steps of segments",
+	}
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List workflow steps of workspace",
+		Example: `qor workflow list --json`,
+		Args:    cobra.NoArgs,
+		PreRun:  startLocalServices(application),
+		Run:     func(cmd *cobra.Command, args []string) { listWorkflows(application, cmd, args) },
+	}
+	moveCmd := &cobra.Command{
+		Use:   "move <file>",
+		Short: "Move segments of a file to a workflow step",
+		Long:  "Moves listed keys (all segments of a file if no key is provided) to a workflow step or back to the first step",
+		Example: `qor workflow move file_name.json --version v1 --key /hotfix/title --key /hotfix/body --step Review
+qor workflow move file_name.json -a fr-fr --first-step`,
+		Args:   cobra.ExactArgs(1),
+		PreRun: startLocalServices(application),
+		Run:    func(cmd *cobra.Command, args []string) { moveSegments(application, cmd, args) },
+	}
+	moveCmd.Flags().StringVarP(&moveVersion, "version", "v", "", "file version")
+	moveCmd.Flags().StringVarP(&moveAudience, "audience", "a", "", "target persona code (default persona where the file was found)")
+	moveCmd.Flags().StringArrayVarP(&moveKeys, "key", "k", nil, "key to move (might be used several times, default all segments of file)")
+	moveCmd.Flags().StringVar(&moveStep, "step", "", "name of workflow step to move segments to")
+	moveCmd.Flags().BoolVar(&isMoveFirstStep, "first-step", false, "move segments back to the first workflow step")
+	addKeySeparatorFlag(moveCmd)
+	workflowCmd.AddCommand(listCmd, moveCmd)
+	return workflowCmd
+}
+
+func listWorkflows(application *app.App, cmd *cobra.Command, args []string) {
+	workspace, err := application.WorkspaceService.LoadWorkspace()
+	if err != nil || workspace == nil {
+		os.Exit(1)
+	}
+	workflows := append([]types.Workflow{}, workspace.Workflow...)
+	sort.SliceStable(workflows, func(i, j int) bool {
+		return workflows[i].Order < workflows[j].Order
+	})
+	data := make([][]string, 0, len(workflows))
+	for _, workflow := range workflows {
+		data = append(data, []string{strconv.Itoa(workflow.ID), workflow.Name, strconv.Itoa(workflow.Order), strconv.FormatBool(workflow.Complete)})
+	}
+	if err := output.Print(&output.Result{Header: workflowHeaders, Rows: data, Value: workflows}); err != nil {
+		log.Errorf("error occurred on output: %v", err)
+	}
+}
+
+func moveSegments(application *app.App, cmd *cobra.Command, args []string) {
+	if application.Config == nil {
+		log.Errorf("error occurred on configuration load")
+		return
+	}
+	if (moveStep == "") == !isMoveFirstStep {
+		log.Errorf("Please provide either `--step` or `--first-step` flag")
+		os.Exit(1)
+	}
+	results, err := application.SegmentService.MoveSegments(args[0], moveVersion, moveAudience, moveKeys, moveStep)
+	if err != nil {
+		log.Errorf("error occurred on workflow move: %v", err)
+		os.Exit(1)
+	}
+	if len(moveKeys) > 0 {
+		printKeyResults(results)
+		return
+	}
+	log.Infof("%d segments of %s were moved", len(results), args[0])
+}
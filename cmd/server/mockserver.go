@@ -0,0 +1,52 @@
+package server
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/mockserver"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	mockAddress string
+	mockPort    int
+	mockData    string
+	mockToken   string
+)
+
+// NewMockServerCommand function create `mock-server` command
+func NewMockServerCommand() *cobra.Command {
+	mockServerCmd := &cobra.Command{
+		Annotations: map[string]string{"group": "dev"},
+		Use:         "mock-server",
+		Short:       "Run local mock of Qordoba API for offline development and tests",
+		Long: `Run local mock of Qordoba API with workspace (organization_id: 1, workspace_id: 1),
+en-us source persona, fr-fr and de-de target personas and Translation, Review, Published workflow steps.
+Point CLI to it with "base_url: http://127.0.0.1:8080" in config`,
+		Example: `"qor mock-server", "qor mock-server --port 9000 --data .qordoba-mock/state.json --token secret"`,
+		Run:     runMockServer,
+	}
+	mockServerCmd.Flags().StringVar(&mockAddress, "address", "127.0.0.1", "Address to listen on")
+	mockServerCmd.Flags().IntVarP(&mockPort, "port", "p", 8080, "Port to listen on")
+	mockServerCmd.Flags().StringVar(&mockData, "data", "", "JSON file keeping server state between runs (default is in-memory state)")
+	mockServerCmd.Flags().StringVar(&mockToken, "token", "", "Accepted access token (default accepts any token)")
+	return mockServerCmd
+}
+
+func runMockServer(cmd *cobra.Command, args []string) {
+	store, err := mockserver.NewStore(mockData)
+	if err != nil {
+		log.Errorf("can't load mock server state: %v", err)
+		os.Exit(1)
+	}
+	address := net.JoinHostPort(mockAddress, strconv.Itoa(mockPort))
+	log.Infof("mock server is listening on http://%s", address)
+	if err = http.ListenAndServe(address, mockserver.New(store, mockToken)); err != nil {
+		log.Errorf("mock server stopped: %v", err)
+		os.Exit(1)
+	}
+}
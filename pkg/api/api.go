@@ -0,0 +1,111 @@
+// Package api is a typed client of Qordoba v3 API. Every endpoint is a method building URL with `net/url`,
+// decoding response and returning *Error with server's response on unsuccessful status
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/rest"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Client sends requests to API endpoints with QordobaClient
+type Client struct {
+	Config        *types.Config
+	QordobaClient pkg.QordobaClient
+}
+
+// Error is an unsuccessful response of API. The same error is returned by rest client
+type Error = rest.StatusError
+
+// StatusCode returns HTTP status of API error or 0 if err is not an API error
+func StatusCode(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// ResponseError returns nil for successful response or *Error with response body. Response body is closed.
+// Unauthorized response stops the command
+func ResponseError(method, requestURL string, resp *http.Response) error {
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		log.Errorf("User is not authorised for this request. Check `access_token` in configuration.")
+		os.Exit(1)
+	}
+	return &Error{
+		Method:     method,
+		URL:        requestURL,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       strings.TrimSpace(string(body)),
+	}
+}
+
+// endpoint builds URL of API with path segments and query
+func (c *Client) endpoint(query url.Values, segments ...interface{}) (string, error) {
+	endpointURL, err := url.Parse(c.Config.GetAPIBase())
+	if err != nil {
+		return "", fmt.Errorf("invalid base_url: %v", err)
+	}
+	path := make([]string, 0, len(segments)+1)
+	path = append(path, strings.TrimSuffix(endpointURL.Path, "/"), "v3")
+	for _, segment := range segments {
+		path = append(path, url.PathEscape(fmt.Sprint(segment)))
+	}
+	endpointURL.RawPath = strings.Join(path, "/")
+	endpointURL.Path, _ = url.PathUnescape(endpointURL.RawPath)
+	endpointURL.RawQuery = query.Encode()
+	return endpointURL.String(), nil
+}
+
+// workspaceEndpoint builds URL of endpoint inside of configured workspace
+func (c *Client) workspaceEndpoint(query url.Values, segments ...interface{}) (string, error) {
+	prefix := []interface{}{"organizations", c.Config.Qordoba.OrganizationID, "workspaces", c.Config.Qordoba.WorkspaceID}
+	return c.endpoint(query, append(prefix, segments...)...)
+}
+
+func (c *Client) get(endpointURL string, result interface{}) error {
+	body, err := c.QordobaClient.GetFromServer(endpointURL)
+	if err != nil {
+		return err
+	}
+	return decode(endpointURL, body, result)
+}
+
+func (c *Client) post(endpointURL string, request interface{}) error {
+	resp, err := c.QordobaClient.PostToServer(endpointURL, request)
+	if err != nil {
+		return err
+	}
+	return ResponseError(http.MethodPost, endpointURL, resp)
+}
+
+func (c *Client) put(endpointURL string, request interface{}) error {
+	resp, err := c.QordobaClient.PutToServer(endpointURL, request)
+	if err != nil {
+		return err
+	}
+	return ResponseError(http.MethodPut, endpointURL, resp)
+}
+
+func decode(endpointURL string, body []byte, result interface{}) error {
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("error occurred on response of %s unmarshalling: %v", endpointURL, err)
+	}
+	return nil
+}
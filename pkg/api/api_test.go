@@ -0,0 +1,78 @@
+package api
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/qordobacode/cli-v2/pkg/mock"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func buildClient(t *testing.T, baseURL string) (*Client, *mock.MockQordobaClient) {
+	controller := gomock.NewController(t)
+	qordobaClient := mock.NewMockQordobaClient(controller)
+	config := &types.Config{BaseURL: baseURL}
+	config.Qordoba.OrganizationID = 1
+	config.Qordoba.WorkspaceID = 2
+	return &Client{Config: config, QordobaClient: qordobaClient}, qordobaClient
+}
+
+func TestClient_ListFilesEscapesQuery(t *testing.T) {
+	client, qordobaClient := buildClient(t, "")
+	qordobaClient.EXPECT().
+		GetFromServer("https://app.qordoba.com/v3/organizations/1/workspaces/2/personas/3/files?filename=en+us%2Fapp%26v%3D1.json&version=v%231&withProgressStatus=true").
+		Return([]byte(`{"files":[{"fileId":5,"filename":"en us/app&v=1.json"}]}`), nil)
+	response, err := client.ListFiles(3, FileQuery{WithProgressStatus: true, Filename: "en us/app&v=1.json", Version: "v#1"})
+	assert.Nil(t, err)
+	assert.Equal(t, 5, response.Files[0].FileID)
+}
+
+func TestClient_EndpointWithBasePath(t *testing.T) {
+	client, qordobaClient := buildClient(t, "http://localhost:8080/api/")
+	qordobaClient.EXPECT().GetFromServer("http://localhost:8080/api/v3/organizations/1/workspaces?limit=500&offset=0").
+		Return([]byte(`{"workspaces":[]}`), nil)
+	_, err := client.ListWorkspaces(500, 0)
+	assert.Nil(t, err)
+}
+
+func TestClient_DecodeError(t *testing.T) {
+	client, qordobaClient := buildClient(t, "")
+	qordobaClient.EXPECT().GetFromServer(gomock.Any()).Return([]byte(`not json`), nil)
+	_, err := client.Score(5, 3, 10)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, StatusCode(err))
+}
+
+func TestClient_KeyAddError(t *testing.T) {
+	client, qordobaClient := buildClient(t, "")
+	qordobaClient.EXPECT().PostToServer("https://app.qordoba.com/v3/organizations/1/workspaces/2/files/5/segments/keyAdd", gomock.Any()).
+		Return(&http.Response{
+			StatusCode: http.StatusNotAcceptable,
+			Status:     "406 Not Acceptable",
+			Body:       ioutil.NopCloser(strings.NewReader(`{"errMessage":"key exists"}` + "\n")),
+		}, nil)
+	err := client.KeyAdd(5, &types.KeyAddRequest{Key: "/title"})
+	assert.EqualError(t, err, `POST https://app.qordoba.com/v3/organizations/1/workspaces/2/files/5/segments/keyAdd: 406 Not Acceptable. Response: {"errMessage":"key exists"}`)
+	assert.Equal(t, http.StatusNotAcceptable, StatusCode(err))
+
+	var apiErr *Error
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, `{"errMessage":"key exists"}`, apiErr.Body)
+}
+
+func TestClient_SegmentUpdate(t *testing.T) {
+	client, qordobaClient := buildClient(t, "")
+	ok := func() *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(""))}
+	}
+	qordobaClient.EXPECT().PutToServer("https://app.qordoba.com/v3/organizations/1/workspaces/2/personas/3/files/5/segments/7/sourceUpdate", gomock.Any()).
+		Return(ok(), nil)
+	qordobaClient.EXPECT().PutToServer("https://app.qordoba.com/v3/organizations/1/workspaces/2/personas/3/files/5/segments/7/targetUpdate", gomock.Any()).
+		Return(ok(), nil)
+	assert.Nil(t, client.SourceUpdate(3, 5, 7, &types.ValueKeyUpdateRequest{Segment: "Hello"}))
+	assert.Nil(t, client.TargetUpdate(3, 5, 7, &types.ValueKeyUpdateRequest{Segment: "Bonjour"}))
+}
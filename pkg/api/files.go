@@ -0,0 +1,99 @@
+package api
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"net/url"
+	"strconv"
+)
+
+// FileQuery filters files of ListFiles
+type FileQuery struct {
+	WithProgressStatus bool
+	// Filename searches files by name and Version if set
+	Filename string
+	Version  string
+	// Limit is max number of files in response, zero means server's default
+	Limit int
+}
+
+// ListFiles returns files of persona
+func (c *Client) ListFiles(personaID int, fileQuery FileQuery) (*types.FileSearchResponse, error) {
+	query := url.Values{"withProgressStatus": {strconv.FormatBool(fileQuery.WithProgressStatus)}}
+	if fileQuery.Filename != "" {
+		query.Set("filename", fileQuery.Filename)
+		query.Set("version", fileQuery.Version)
+	}
+	if fileQuery.Limit > 0 {
+		query.Set("limit", strconv.Itoa(fileQuery.Limit))
+	}
+	endpointURL, err := c.workspaceEndpoint(query, "personas", personaID, "files")
+	if err != nil {
+		return nil, err
+	}
+	var response types.FileSearchResponse
+	if err = c.get(endpointURL, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// UpsertFile creates file or a new revision of file with the same name and version
+func (c *Client) UpsertFile(request *types.PushRequest) error {
+	prefix := []interface{}{"files", "organizations", c.Config.Qordoba.OrganizationID, "workspaces", c.Config.Qordoba.WorkspaceID, "upsert"}
+	endpointURL, err := c.endpoint(nil, prefix...)
+	if err != nil {
+		return err
+	}
+	return c.post(endpointURL, request)
+}
+
+// DownloadFile returns content of file translated into persona
+func (c *Client) DownloadFile(personaID, fileID int) ([]byte, error) {
+	endpointURL, err := c.workspaceEndpoint(nil, "personas", personaID, "files", fileID, "download")
+	if err != nil {
+		return nil, err
+	}
+	return c.QordobaClient.GetFromServer(endpointURL)
+}
+
+// DownloadSourceFile returns content of source file, with updates of source segments if withUpdates is set
+func (c *Client) DownloadSourceFile(fileID int, withUpdates bool) ([]byte, error) {
+	query := url.Values{"withUpdates": {strconv.FormatBool(withUpdates)}}
+	endpointURL, err := c.workspaceEndpoint(query, "files", fileID, "download", "source")
+	if err != nil {
+		return nil, err
+	}
+	return c.QordobaClient.GetFromServer(endpointURL)
+}
+
+// DeleteFile deletes file
+func (c *Client) DeleteFile(fileID int) (*types.FileDeleteResponse, error) {
+	endpointURL, err := c.workspaceEndpoint(nil, "files", fileID)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.QordobaClient.DeleteFromServer(endpointURL)
+	if err != nil {
+		return nil, err
+	}
+	var response types.FileDeleteResponse
+	if err = decode(endpointURL, body, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Score returns content score of file for persona. Document length is a number of words in file
+func (c *Client) Score(fileID, personaID, documentLength int) (*types.ScoreResponseBody, error) {
+	query := url.Values{"documentLength": {strconv.Itoa(documentLength)}}
+	prefix := []interface{}{"contentscore", "organizations", c.Config.Qordoba.OrganizationID, "workspaces", c.Config.Qordoba.WorkspaceID}
+	endpointURL, err := c.endpoint(query, append(prefix, "documents", fileID, "personas", personaID, "score")...)
+	if err != nil {
+		return nil, err
+	}
+	var response types.ScoreResponseBody
+	if err = c.get(endpointURL, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
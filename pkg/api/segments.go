@@ -0,0 +1,83 @@
+package api
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"net/url"
+	"strconv"
+)
+
+// SegmentQuery filters and pages segments of ListSegments
+type SegmentQuery struct {
+	// Search returns only segments matching key or text
+	Search string
+	// Limit is a page size, zero means no paging
+	Limit  int
+	Offset int
+}
+
+// ListSegments returns segments of file in workflow step for persona
+func (c *Client) ListSegments(personaID, fileID, workflowID int, segmentQuery SegmentQuery) (*types.SegmentSearchResponse, error) {
+	query := url.Values{}
+	if segmentQuery.Limit > 0 {
+		query.Set("limit", strconv.Itoa(segmentQuery.Limit))
+		query.Set("offset", strconv.Itoa(segmentQuery.Offset))
+	}
+	if segmentQuery.Search != "" {
+		query.Set("search", segmentQuery.Search)
+	}
+	endpointURL, err := c.workspaceEndpoint(query, "personas", personaID, "files", fileID, "workflow", workflowID, "segments")
+	if err != nil {
+		return nil, err
+	}
+	var response types.SegmentSearchResponse
+	if err = c.get(endpointURL, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// KeyAdd adds segment with a new key into file. Existing key is reported with 406 status
+func (c *Client) KeyAdd(fileID int, request *types.KeyAddRequest) error {
+	endpointURL, err := c.workspaceEndpoint(nil, "files", fileID, "segments", "keyAdd")
+	if err != nil {
+		return err
+	}
+	return c.post(endpointURL, request)
+}
+
+// SourceUpdate updates source text of segment
+func (c *Client) SourceUpdate(personaID, fileID, segmentID int, request *types.ValueKeyUpdateRequest) error {
+	return c.segmentUpdate("sourceUpdate", personaID, fileID, segmentID, request)
+}
+
+// TargetUpdate updates translation of segment into persona
+func (c *Client) TargetUpdate(personaID, fileID, segmentID int, request *types.ValueKeyUpdateRequest) error {
+	return c.segmentUpdate("targetUpdate", personaID, fileID, segmentID, request)
+}
+
+func (c *Client) segmentUpdate(action string, personaID, fileID, segmentID int, request *types.ValueKeyUpdateRequest) error {
+	endpointURL, err := c.workspaceEndpoint(nil, "personas", personaID, "files", fileID, "segments", segmentID, action)
+	if err != nil {
+		return err
+	}
+	return c.put(endpointURL, request)
+}
+
+// KeyDelete deletes segment from file
+func (c *Client) KeyDelete(fileID, segmentID int) error {
+	endpointURL, err := c.workspaceEndpoint(nil, "files", fileID, "segments", segmentID, "keyDelete")
+	if err != nil {
+		return err
+	}
+	_, err = c.QordobaClient.DeleteFromServer(endpointURL)
+	return err
+}
+
+// WorkflowMove moves segments of file to workflow step
+func (c *Client) WorkflowMove(personaID, fileID int, request *types.WorkflowMoveRequest) error {
+	endpointURL, err := c.workspaceEndpoint(nil, "personas", personaID, "files", fileID, "segments", "workflow", "move")
+	if err != nil {
+		return err
+	}
+	return c.post(endpointURL, request)
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"net/url"
+	"strconv"
+)
+
+// ListWorkspaces returns page of organization's workspaces
+func (c *Client) ListWorkspaces(limit, offset int) (*types.WorkspaceResponse, error) {
+	query := url.Values{"limit": {strconv.Itoa(limit)}, "offset": {strconv.Itoa(offset)}}
+	endpointURL, err := c.endpoint(query, "organizations", c.Config.Qordoba.OrganizationID, "workspaces")
+	if err != nil {
+		return nil, err
+	}
+	var response types.WorkspaceResponse
+	if err = c.get(endpointURL, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
@@ -0,0 +1,149 @@
+// Package app wires configuration and services used by CLI commands into a single container
+package app
+
+import (
+	"github.com/qordobacode/cli-v2/pkg"
+	"github.com/qordobacode/cli-v2/pkg/config"
+	"github.com/qordobacode/cli-v2/pkg/file"
+	"github.com/qordobacode/cli-v2/pkg/general"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/rest"
+	"github.com/qordobacode/cli-v2/pkg/segments"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/qordobacode/cli-v2/pkg/workspace"
+	"os"
+	"time"
+)
+
+// App is a container of configuration and services used by commands. It's created once by root command,
+// config is loaded and services are wired by Init when command, which needs them, is run
+type App struct {
+	Config               *types.Config
+	Local                pkg.Local
+	QordobaClient        pkg.QordobaClient
+	ConfigurationService pkg.ConfigurationService
+	WorkspaceService     pkg.WorkspaceService
+	FileService          pkg.FileService
+	SegmentService       pkg.SegmentService
+	// Logger is set to all services. Default logger is used if it's nil
+	Logger log.Logger
+	// Now and Sleep are used by commands instead of time.Now and time.Sleep
+	Now   func() time.Time
+	Sleep func(d time.Duration)
+
+	isInitialized bool
+}
+
+// Option overrides part of application, e.g. to run commands against fake server in tests
+type Option func(a *App)
+
+// WithConfig sets config instead of loading it from files
+func WithConfig(config *types.Config) Option {
+	return func(a *App) {
+		a.Config = config
+	}
+}
+
+// WithClient sets client used by services instead of REST client built from config
+func WithClient(client pkg.QordobaClient) Option {
+	return func(a *App) {
+		a.QordobaClient = client
+	}
+}
+
+// WithLocal sets filesystem access used by services
+func WithLocal(local pkg.Local) Option {
+	return func(a *App) {
+		a.Local = local
+	}
+}
+
+// WithLogger sets logger used by services and REST client built from config
+func WithLogger(logger log.Logger) Option {
+	return func(a *App) {
+		a.Logger = logger
+	}
+}
+
+// WithClock sets functions returning current time and pausing execution
+func WithClock(now func() time.Time, sleep func(d time.Duration)) Option {
+	return func(a *App) {
+		a.Now = now
+		a.Sleep = sleep
+	}
+}
+
+// New creates application with options applied. Config is loaded later by Init
+func New(options ...Option) *App {
+	a := &App{
+		Local: &general.Local{},
+		Now:   time.Now,
+		Sleep: time.Sleep,
+	}
+	for _, option := range options {
+		option(a)
+	}
+	a.ConfigurationService = &config.ConfigurationService{Local: a.Local}
+	return a
+}
+
+// Init loads config, if it wasn't set by option, and wires services with it. Next calls do nothing
+func (a *App) Init() error {
+	if a.isInitialized {
+		return nil
+	}
+	if a.Config == nil {
+		appConfig, err := a.ConfigurationService.LoadConfig()
+		if err != nil {
+			return err
+		}
+		a.Config = appConfig
+	}
+	if local, ok := a.Local.(*general.Local); ok {
+		local.Config = a.Config
+	}
+	if a.QordobaClient == nil {
+		client, err := rest.NewClient(a.Config)
+		if err != nil {
+			log.OrDefault(a.Logger).Errorf("invalid HTTP configuration: %v", err)
+			return err
+		}
+		client.Logger = a.Logger
+		if tracingTransport, ok := client.HTTPClient.Transport.(*rest.TracingTransport); ok {
+			tracingTransport.Logger = a.Logger
+		}
+		a.QordobaClient = client
+	}
+	workspaceService := &workspace.Service{
+		Config:        a.Config,
+		QordobaClient: a.QordobaClient,
+		Local:         a.Local,
+		Logger:        a.Logger,
+	}
+	fileService := &file.Service{
+		Config:           a.Config,
+		WorkspaceService: workspaceService,
+		Local:            a.Local,
+		QordobaClient:    a.QordobaClient,
+		Logger:           a.Logger,
+		Sleep:            a.Sleep,
+	}
+	a.WorkspaceService = workspaceService
+	a.FileService = fileService
+	a.SegmentService = &segments.SegmentService{
+		Config:           a.Config,
+		FileService:      fileService,
+		QordobaClient:    a.QordobaClient,
+		WorkspaceService: workspaceService,
+		Logger:           a.Logger,
+	}
+	a.isInitialized = true
+	return nil
+}
+
+// MustInit calls Init and exits if config can't be loaded. It's used as PreRun of commands
+func (a *App) MustInit() {
+	if err := a.Init(); err != nil {
+		os.Exit(1)
+	}
+}
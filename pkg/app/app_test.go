@@ -0,0 +1,57 @@
+package app
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/qordobacode/cli-v2/pkg/file"
+	"github.com/qordobacode/cli-v2/pkg/general"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/mock"
+	"github.com/qordobacode/cli-v2/pkg/rest"
+	"github.com/qordobacode/cli-v2/pkg/segments"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/qordobacode/cli-v2/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestApp_InitWithOptions(t *testing.T) {
+	client := mock.NewMockQordobaClient(gomock.NewController(t))
+	config := &types.Config{}
+	var slept time.Duration
+	application := New(WithConfig(config), WithClient(client), WithClock(time.Now, func(d time.Duration) { slept += d }))
+
+	assert.Nil(t, application.Init())
+	assert.Equal(t, client, application.QordobaClient)
+	assert.Equal(t, config, application.Local.(*general.Local).Config)
+	fileService := application.FileService.(*file.Service)
+	assert.Equal(t, application.WorkspaceService, fileService.WorkspaceService)
+	fileService.Sleep(time.Second)
+	assert.Equal(t, time.Second, slept)
+
+	segmentService := application.SegmentService
+	assert.Nil(t, application.Init())
+	assert.Equal(t, segmentService, application.SegmentService)
+}
+
+func TestApp_WithLogger(t *testing.T) {
+	logger := log.New(log.DebugLevel, log.TextFormat, ioutil.Discard)
+	application := New(WithConfig(&types.Config{}), WithLogger(logger))
+
+	assert.Nil(t, application.Init())
+	assert.Equal(t, logger, application.QordobaClient.(*rest.Client).Logger)
+	assert.Equal(t, logger, application.WorkspaceService.(*workspace.Service).Logger)
+	assert.Equal(t, logger, application.FileService.(*file.Service).Logger)
+	assert.Equal(t, logger, application.SegmentService.(*segments.SegmentService).Logger)
+}
+
+func TestApp_ConfigurationServiceUsesLocal(t *testing.T) {
+	local := mock.NewMockLocal(gomock.NewController(t))
+	local.EXPECT().Read("config.yaml").Return([]byte("base_url: http://localhost:8080"), nil)
+	application := New(WithLocal(local))
+
+	config, err := application.ConfigurationService.ReadConfigInPath("config.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://localhost:8080", config.BaseURL)
+}
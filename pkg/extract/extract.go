@@ -0,0 +1,145 @@
+package extract
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// skippedDirs are never scanned for keys
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"build":        true,
+	"dist":         true,
+}
+
+// DefaultPatterns finds keys of Go i18n calls, JS/TS `t('key')` calls and Android `R.string.key` references
+var DefaultPatterns = []Pattern{
+	{
+		Extensions: []string{".go"},
+		Regexp:     regexp.MustCompile(`(?:\bi18n\.[A-Z]\w*\(\s*|\bMessageID:\s*|&i18n\.Message\{\s*ID:\s*)"([^"\\]+)"`),
+	},
+	{
+		Extensions: []string{".js", ".jsx", ".ts", ".tsx", ".vue"},
+		Regexp:     regexp.MustCompile(`(?:\$t|\bt)\(\s*['"]([^'"\\]+)['"]`),
+	},
+	{
+		Extensions: []string{".java", ".kt"},
+		Regexp:     regexp.MustCompile(`\bR\.string\.(\w+)`),
+	},
+	{
+		Extensions: []string{".xml"},
+		Regexp:     regexp.MustCompile(`@string/(\w+)`),
+	},
+}
+
+// Pattern is a regular expression, which first group captures key. Pattern without extensions is applied to all files
+type Pattern struct {
+	Extensions []string
+	Regexp     *regexp.Regexp
+}
+
+// Reference is a key usage found in source code
+type Reference struct {
+	Key  string `json:"key"`
+	Path string `json:"path"`
+	Line int    `json:"line"`
+}
+
+func (r Reference) String() string {
+	return fmt.Sprintf("%s:%d", r.Path, r.Line)
+}
+
+// NewPattern compiles custom pattern, which is applied to all scanned files
+func NewPattern(expression string) (Pattern, error) {
+	compiled, err := regexp.Compile(expression)
+	if err != nil {
+		return Pattern{}, err
+	}
+	if compiled.NumSubexp() < 1 {
+		return Pattern{}, fmt.Errorf("pattern `%s` has no group to capture key", expression)
+	}
+	return Pattern{Regexp: compiled}, nil
+}
+
+func (p Pattern) matches(path string) bool {
+	if len(p.Extensions) == 0 {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, extension := range p.Extensions {
+		if ext == extension {
+			return true
+		}
+	}
+	return false
+}
+
+// Scan walks through files and folders and returns all key references in order of their appearance.
+// Hidden folders, dependencies and binary files are skipped
+func Scan(paths []string, patterns []Pattern) ([]Reference, error) {
+	references := make([]Reference, 0)
+	for _, root := range paths {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				name := info.Name()
+				if path != root && (strings.HasPrefix(name, ".") || skippedDirs[name]) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			found, err := scanFile(path, patterns)
+			references = append(references, found...)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return references, nil
+}
+
+func scanFile(path string, patterns []Pattern) ([]Reference, error) {
+	filePatterns := make([]Pattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern.matches(path) {
+			filePatterns = append(filePatterns, pattern)
+		}
+	}
+	if len(filePatterns) == 0 {
+		return nil, nil
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.IndexByte(content, 0) >= 0 {
+		return nil, nil
+	}
+	references := make([]Reference, 0)
+	for _, pattern := range filePatterns {
+		for _, match := range pattern.Regexp.FindAllSubmatchIndex(content, -1) {
+			if match[2] < 0 {
+				continue
+			}
+			references = append(references, Reference{
+				Key:  string(content[match[2]:match[3]]),
+				Path: path,
+				Line: bytes.Count(content[:match[2]], []byte("\n")) + 1,
+			})
+		}
+	}
+	sort.SliceStable(references, func(i, j int) bool {
+		return references[i].Line < references[j].Line
+	})
+	return references, nil
+}
@@ -0,0 +1,54 @@
+package extract
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	dir, err := ioutil.TempDir("", "extract")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	sources := map[string]string{
+		"main.go":                 "msg := localizer.MustLocalize(&i18n.LocalizeConfig{MessageID: \"greeting\"})\n",
+		"app/page.tsx":            "<h1>{t('page.title')}</h1>\n<p>{i18n.t(\"page.body\")}</p>\n",
+		"android/Main.java":       "getString(R.string.app_name);\n",
+		"android/layout/main.xml": "<TextView android:text=\"@string/app_name\"/>\n",
+		"node_modules/lib/x.js":   "t('ignored')\n",
+		"README.md":               "t('not.scanned')\n",
+	}
+	for name, content := range sources {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	references, err := Scan([]string{dir}, DefaultPatterns)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"greeting", "page.title", "page.body", "app_name"}, keys(references))
+	assert.Contains(t, references, Reference{Key: "page.body", Path: filepath.Join(dir, "app/page.tsx"), Line: 2})
+
+	custom, err := NewPattern(`translate\("([^"]+)"\)`)
+	assert.Nil(t, err)
+	references, err = Scan([]string{dir}, []Pattern{custom})
+	assert.Nil(t, err)
+	assert.Empty(t, references)
+
+	_, err = NewPattern(`translate`)
+	assert.NotNil(t, err)
+}
+
+// keys returns unique keys of references in order of their first appearance
+func keys(references []Reference) []string {
+	result := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, reference := range references {
+		if !seen[reference.Key] {
+			seen[reference.Key] = true
+			result = append(result, reference.Key)
+		}
+	}
+	return result
+}
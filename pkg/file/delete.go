@@ -1,14 +1,13 @@
 package file
 
 import (
-	"fmt"
-	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/api"
 	"github.com/qordobacode/cli-v2/pkg/types"
 )
 
 // DeleteFile function retrieve file and delete it
 func (f *Service) DeleteFile(fileName, version string) {
-	log.Debugf("deleteFoundFile was called for file '%v'('%v')", fileName, version)
+	f.logger().Debugf("deleteFoundFile was called for file '%v'('%v')", fileName, version)
 	file, _ := f.FindFile(fileName, version, false)
 	if file != nil {
 		f.deleteFoundFile(file)
@@ -17,21 +16,16 @@ func (f *Service) DeleteFile(fileName, version string) {
 
 // deleteFoundFile func delete file from parameters
 func (f *Service) deleteFoundFile(file *types.File) {
-	base := f.Config.GetAPIBase()
-	deleteFileURL := fmt.Sprintf(fileDeleteTemplate, base, f.Config.Qordoba.OrganizationID, f.Config.Qordoba.WorkspaceID, file.FileID)
-	bytes, err := f.QordobaClient.DeleteFromServer(deleteFileURL)
+	deleteResponse, err := f.apiClient().DeleteFile(file.FileID)
 	if err != nil {
-		return
-	}
-	var deleteResponse types.FileDeleteResponse
-	err = deleteResponse.UnmarshalJSON(bytes)
-	if err != nil {
-		log.Errorf("error occurred on delete response unmarshalling: %v", err)
+		if api.StatusCode(err) == 0 {
+			f.logger().Errorf("%v", err)
+		}
 		return
 	}
 	if deleteResponse.Success {
-		log.Infof("File '%s' with version '%s' was removed", file.Filename, file.Version)
+		f.logger().Infof("File '%s' with version '%s' was removed", file.Filename, file.Version)
 	} else {
-		log.Errorf("File '%s' with version '%s' WAS NOT REMOVED", file.Filename, file.Version)
+		f.logger().Errorf("File '%s' with version '%s' WAS NOT REMOVED", file.Filename, file.Version)
 	}
 }
@@ -1,7 +1,6 @@
 package file
 
 import (
-	"fmt"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"os"
@@ -10,40 +9,41 @@ import (
 )
 
 // DownloadFile function retrieves file in workspace
-func (f *Service) DownloadFile(persona types.Person, fileName string, file *types.File) {
+func (f *Service) DownloadFile(persona types.Person, fileName string, file *types.File) error {
 	start := time.Now()
 	defer func() {
 		log.TimeTrack(start, "DownloadFile")
 	}()
-	base := f.Config.GetAPIBase()
-	getFileContentURL := fmt.Sprintf(fileDownloadTemplate, base, f.Config.Qordoba.OrganizationID, f.Config.Qordoba.WorkspaceID, persona.ID, file.FileID)
-	f.handleDownloadedFile(getFileContentURL, fileName, persona.Code)
+	fileBytesResponse, err := f.apiClient().DownloadFile(persona.ID, file.FileID)
+	return f.handleDownloadedFile(fileBytesResponse, err, fileName, persona.Code)
 }
 
-func (f *Service) handleDownloadedFile(fileRemoteURL, fileName, language string) {
-	fileBytesResponse, err := f.QordobaClient.GetFromServer(fileRemoteURL)
-	if err != nil {
-		log.Errorf("error occurred on file %s download (url = %s)\n%v", fileName, fileRemoteURL, err.Error())
-		return
+func (f *Service) handleDownloadedFile(fileBytesResponse []byte, err error, fileName, language string) error {
+	logger := f.logger().WithFields(log.Fields{"file": fileName})
+	if language != "" {
+		logger = logger.WithFields(log.Fields{"persona": language})
 	}
-	if len(f.Config.Push.Sources.Folders) > 0 {
-		fileName = filepath.Join(f.Config.Push.Sources.Folders[0], fileName)
+	if err != nil {
+		logger.Errorf("error occurred on file %s download\n%v", fileName, err)
+		return err
 	}
+	fileName = f.Config.DownloadPath(fileName)
 	err = os.MkdirAll(filepath.Dir(fileName), 0755)
 	if err != nil {
-		log.Errorf("error occurred on creating new directories")
+		logger.Errorf("error occurred on creating new directories")
+		return err
 	}
 	f.Local.Write(fileName, fileBytesResponse)
 	if language == "" {
-		log.Infof("file %s was downloaded", fileName)
+		logger.Infof("file %s was downloaded", fileName)
 	} else {
-		log.Infof("file %s was downloaded for language %s", fileName, language)
+		logger.Infof("file %s was downloaded for language %s", fileName, language)
 	}
+	return nil
 }
 
 // DownloadSourceFile function retrieves all source files in workspace
-func (f *Service) DownloadSourceFile(fileName string, file *types.File, withUpdates bool) {
-	base := f.Config.GetAPIBase()
-	getFileContentURL := fmt.Sprintf(sourceFileDownloadTemplate, base, f.Config.Qordoba.OrganizationID, f.Config.Qordoba.WorkspaceID, file.FileID, withUpdates)
-	f.handleDownloadedFile(getFileContentURL, fileName, "")
+func (f *Service) DownloadSourceFile(fileName string, file *types.File, withUpdates bool) error {
+	fileBytesResponse, err := f.apiClient().DownloadSourceFile(file.FileID, withUpdates)
+	return f.handleDownloadedFile(fileBytesResponse, err, fileName, "")
 }
@@ -1,29 +1,42 @@
 package file
 
 import (
-	"fmt"
 	"github.com/qordobacode/cli-v2/pkg"
+	"github.com/qordobacode/cli-v2/pkg/api"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"strconv"
 	"time"
 )
 
-const (
-	fileListURLTemplate          = "%s/v3/organizations/%d/workspaces/%d/personas/%d/files?withProgressStatus=%v"
-	fileListURLTemplateWithLimit = "%s/v3/organizations/%d/workspaces/%d/personas/%d/files?withProgressStatus=%v&limit=%v"
-	fileSearchURLTemplate        = "%s/v3/organizations/%d/workspaces/%d/personas/%d/files?withProgressStatus=%v&filename=%v&version=%v"
-	fileDownloadTemplate         = "%s/v3/organizations/%d/workspaces/%d/personas/%d/files/%d/download"
-	sourceFileDownloadTemplate   = "%s/v3/organizations/%d/workspaces/%d/files/%d/download/source?withUpdates=%v"
-	fileDeleteTemplate           = "%s/v3/organizations/%d/workspaces/%d/files/%d"
-)
-
 // Service implements pkg.Service
 type Service struct {
 	Config           *types.Config
 	QordobaClient    pkg.QordobaClient
 	WorkspaceService pkg.WorkspaceService
 	Local            pkg.Local
+	// SkipValidation disables resource files validation before push
+	SkipValidation bool
+	// Sleep is used instead of time.Sleep if set
+	Sleep func(d time.Duration)
+	// Logger reports progress and errors of file operations
+	Logger log.Logger
+}
+
+func (f *Service) logger() log.Logger {
+	return log.OrDefault(f.Logger)
+}
+
+func (f *Service) sleep(d time.Duration) {
+	if f.Sleep == nil {
+		time.Sleep(d)
+		return
+	}
+	f.Sleep(d)
+}
+
+func (f *Service) apiClient() *api.Client {
+	return &api.Client{Config: f.Config, QordobaClient: f.QordobaClient}
 }
 
 // WorkspaceFiles function retrieves all files in workspace
@@ -32,9 +45,7 @@ func (f *Service) WorkspaceFiles(personaID int, withProgressStatus bool) (*types
 	defer func() {
 		log.TimeTrack(start, "WorkspaceFiles "+strconv.Itoa(personaID))
 	}()
-	base := f.Config.GetAPIBase()
-	fileListURL := fmt.Sprintf(fileListURLTemplate, base, f.Config.Qordoba.OrganizationID, f.Config.Qordoba.WorkspaceID, personaID, withProgressStatus)
-	return f.callFileRequestAndHandle(fileListURL)
+	return f.listFiles(personaID, api.FileQuery{WithProgressStatus: withProgressStatus})
 }
 
 // WorkspaceFilesWithLimit function retrieves limited number of files from workspace
@@ -43,45 +54,36 @@ func (f *Service) WorkspaceFilesWithLimit(personaID int, withProgressStatus bool
 	defer func() {
 		log.TimeTrack(start, "WorkspaceFilesWithLimit "+strconv.Itoa(personaID))
 	}()
-	base := f.Config.GetAPIBase()
-	fileListURL := fmt.Sprintf(fileListURLTemplateWithLimit, base, f.Config.Qordoba.OrganizationID, f.Config.Qordoba.WorkspaceID, personaID, withProgressStatus, limit)
-	return f.callFileRequestAndHandle(fileListURL)
+	return f.listFiles(personaID, api.FileQuery{WithProgressStatus: withProgressStatus, Limit: limit})
 }
 
-func (f *Service) callFileRequestAndHandle(getUserFiles string) (*types.FileSearchResponse, error) {
-	fileBytesResponse, err := f.QordobaClient.GetFromServer(getUserFiles)
-	if err != nil {
-		return nil, err
-	}
-	var response types.FileSearchResponse
-	err = response.UnmarshalJSON(fileBytesResponse)
-	if err != nil {
-		log.Errorf("error occurred on server response unmarshalling: %v", err)
-		return nil, err
+func (f *Service) listFiles(personaID int, query api.FileQuery) (*types.FileSearchResponse, error) {
+	response, err := f.apiClient().ListFiles(personaID, query)
+	if err != nil && api.StatusCode(err) == 0 {
+		f.logger().Errorf("%v", err)
 	}
-	return &response, nil
+	return response, err
 }
 
 // FindFile function search for file by its name and version
 // Returns file if it was found AND Persona_ID, for which that file was found
 func (f *Service) FindFile(fileName, version string, withProgressStatus bool) (*types.File, int) {
 	if version != "" {
-		log.Debugf("FindFile was called for file '%v %v')", fileName, version)
+		f.logger().Debugf("FindFile was called for file '%v %v')", fileName, version)
 	} else {
-		log.Debugf("FindFile was called for file '%v'", fileName)
+		f.logger().Debugf("FindFile was called for file '%v'", fileName)
 	}
 	if fileName == "" {
-		log.Errorf("file name can't be empty")
+		f.logger().Errorf("file name can't be empty")
 		return nil, 0
 	}
 	workspace, err := f.WorkspaceService.LoadWorkspace()
 	if err != nil {
 		return nil, 0
 	}
-	base := f.Config.GetAPIBase()
 	for _, persona := range workspace.Workspace.TargetPersonas {
-		fileListURL := fmt.Sprintf(fileSearchURLTemplate, base, f.Config.Qordoba.OrganizationID, f.Config.Qordoba.WorkspaceID, persona.ID, withProgressStatus, fileName, version)
-		fileSearchResponse, err := f.callFileRequestAndHandle(fileListURL)
+		query := api.FileQuery{WithProgressStatus: withProgressStatus, Filename: fileName, Version: version}
+		fileSearchResponse, err := f.listFiles(persona.ID, query)
 		if err != nil {
 			continue
 		}
@@ -94,9 +96,9 @@ func (f *Service) FindFile(fileName, version string, withProgressStatus bool) (*
 		}
 	}
 	if version == "" {
-		log.Errorf("File '%s' WAS NOT FOUND", fileName)
+		f.logger().Errorf("File '%s' WAS NOT FOUND", fileName)
 	} else {
-		log.Errorf("File '%s' with version '%s' WAS NOT FOUND", fileName, version)
+		f.logger().Errorf("File '%s' with version '%s' WAS NOT FOUND", fileName, version)
 	}
 	return nil, 0
 }
@@ -3,6 +3,7 @@ package file
 import (
 	"errors"
 	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/api"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"io/ioutil"
@@ -16,27 +17,30 @@ import (
 	"time"
 )
 
-const (
-	pushFileTemplate = "%s/v3/files/organizations/%d/workspaces/%d/upsert"
-	concurrencyLevel = 1
-)
+const concurrencyLevel = 1
 
 var (
 	TotalSkipped uint64
 	MimeTypes    string
+
+	errNotSourceName = errors.New("file not pass source name")
+	errMimeType      = errors.New("file not pass mime type check")
 )
 
 // PushFolder function push folder to server
-func (f *Service) PushFolder(folder, version string, isRecursive bool) {
+func (f *Service) PushFolder(folder, version string, isRecursive bool) []*types.FileResult {
 	fileList := f.Local.FilesInFolder(folder, isRecursive)
-	f.PushFiles(fileList, version, isRecursive)
+	return f.PushFiles(fileList, version, isRecursive)
 }
 
-// PushFiles function push array of files to server with specified version
-func (f *Service) PushFiles(fileList []string, version string, isFilepath bool) {
+// PushFiles function push array of files to server with specified version and returns result of every pushed file
+func (f *Service) PushFiles(fileList []string, version string, isFilepath bool) []*types.FileResult {
 	jobs := make(chan *pushFileTask, 1000)
-	results := make(chan struct{}, 1000)
+	results := make(chan *types.FileResult, 1000)
 	filteredFileList := f.filterFiles(fileList)
+	if !f.SkipValidation && !f.validateBeforePush(filteredFileList) {
+		os.Exit(1)
+	}
 
 	workspace, err := f.WorkspaceService.LoadWorkspace()
 	if err != nil {
@@ -56,15 +60,17 @@ func (f *Service) PushFiles(fileList []string, version string, isFilepath bool)
 	}
 
 	// let all error logs go before final messages
-	time.Sleep(time.Second)
+	f.sleep(time.Second)
 	totalFilesPushed := 0
 	for i := range filteredFileList {
 		totalFilesPushed += f.pushFile(filteredFileList[i], jobs)
 	}
 	close(jobs)
+	fileResults := make([]*types.FileResult, 0, totalFilesPushed)
 	for i := 0; i < totalFilesPushed; i++ {
-		<-results
+		fileResults = append(fileResults, <-results)
 	}
+	return fileResults
 }
 
 func (f *Service) filterFiles(files []string) []string {
@@ -75,7 +81,7 @@ fileSearch:
 	for _, file := range files {
 		for _, blackReg := range blacklistRegexp {
 			if blackReg.FindString(file) != "" {
-				log.Infof("file %s is not pushed due to black list", file)
+				f.logger().Infof("file %s is not pushed due to black list", file)
 				continue fileSearch
 			}
 		}
@@ -89,7 +95,7 @@ func (f *Service) buildBlacklistRegexps() []*regexp.Regexp {
 	for _, blackList := range f.Config.Blacklist.Sources {
 		compile, err := regexp.Compile(blackList)
 		if err != nil {
-			log.Errorf("invalid blacklist regexp '%s': %v\n", blackList, err)
+			f.logger().Errorf("invalid blacklist regexp '%s': %v\n", blackList, err)
 			os.Exit(1)
 		}
 		blacklistRegexp = append(blacklistRegexp, compile)
@@ -97,23 +103,21 @@ func (f *Service) buildBlacklistRegexps() []*regexp.Regexp {
 	return blacklistRegexp
 }
 
-func (f *Service) startPushWorker(jobs chan *pushFileTask, results chan struct{}, version string,
+func (f *Service) startPushWorker(jobs chan *pushFileTask, results chan *types.FileResult, version string,
 	workspace *types.WorkspaceData, contentTypeCodes map[string]struct{}, isFilepath bool) {
-	base := f.Config.GetAPIBase()
-	pushFileURL := fmt.Sprintf(pushFileTemplate, base, f.Config.Qordoba.OrganizationID, f.Config.Qordoba.WorkspaceID)
 	for j := range jobs {
-		f.sendFileToServer(j.fileInfo, j.FilePath, pushFileURL, version, results, workspace, contentTypeCodes, isFilepath)
+		f.sendFileToServer(j.fileInfo, j.FilePath, version, results, workspace, contentTypeCodes, isFilepath)
 	}
 }
 
 func (f *Service) pushFile(filePath string, jobs chan *pushFileTask) int {
 	fileInfo, err := os.Stat(filePath)
 	if os.IsNotExist(err) {
-		log.Errorf("file %s doesn't exist", filePath)
+		f.logger().Errorf("file %s doesn't exist", filePath)
 		return 0
 	}
 	if err != nil {
-		log.Errorf("error occurred on file read: %v", err)
+		f.logger().Errorf("error occurred on file read: %v", err)
 		return 0
 	}
 	if fileInfo.IsDir() {
@@ -131,42 +135,47 @@ type pushFileTask struct {
 	fileInfo os.FileInfo
 }
 
-func (f *Service) sendFileToServer(fileInfo os.FileInfo, filePath, pushFileURL, version string, results chan struct{},
+func (f *Service) sendFileToServer(fileInfo os.FileInfo, filePath, version string, results chan *types.FileResult,
 	workspace *types.WorkspaceData, contentTypeCodes map[string]struct{}, isFilepath bool) {
+	result := &types.FileResult{File: filePath, Version: version, Status: types.FileFailed}
+	logger := f.logger().WithFields(log.Fields{"file": filePath})
 	defer func() {
 		if err := recover(); err != nil {
-			fmt.Printf("Recovered in sendFileToServer: %v\n%s\n", err, debug.Stack())
+			logger.Errorf("Recovered in sendFileToServer: %v\n%s", err, debug.Stack())
+			result.Status, result.Error = types.FileFailed, fmt.Sprintf("%v", err)
 		}
-		results <- struct{}{}
+		results <- result
 	}()
 	if fileInfo.IsDir() {
+		result.Status = types.FileSkipped
 		return
 	}
 	pushRequest, err := f.buildPushRequest(fileInfo, filePath, version, workspace, contentTypeCodes, isFilepath)
 	if err != nil {
+		if err == errNotSourceName || err == errMimeType {
+			result.Status = types.FileSkipped
+		}
+		result.Error = err.Error()
 		return
 	}
-	resp, err := f.QordobaClient.PostToServer(pushFileURL, pushRequest)
-	if err != nil {
-		log.Errorf("error occurred on post to server: %v", err)
-		return
-	}
-	body, _ := ioutil.ReadAll(resp.Body)
-	if resp.StatusCode/100 != 2 {
-		if resp.StatusCode == http.StatusUnauthorized {
-			log.Errorf("User is not authorised for this request. Check `access_token` in configuration.")
-			os.Exit(1)
-		}
-		if resp.StatusCode == http.StatusRequestEntityTooLarge {
-			log.Errorf("File %v (%v bytes) is too large for server. %v", fileInfo.Name(), fileInfo.Size(), string(body))
-		} else {
-			log.Errorf("File %s push status: %v. Response: %v", filePath, resp.Status, string(body))
-		}
-	} else {
+	err = f.apiClient().UpsertFile(pushRequest)
+	var apiErr *api.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusRequestEntityTooLarge:
+		logger.Errorf("File %v (%v bytes) is too large for server. %v", fileInfo.Name(), fileInfo.Size(), apiErr.Body)
+		result.Error = apiErr.Status
+	case apiErr != nil:
+		logger.Errorf("File %s push status: %v. Response: %v", filePath, apiErr.Status, apiErr.Body)
+		result.Error = apiErr.Status
+	case err != nil:
+		logger.Errorf("error occurred on post to server: %v", err)
+		result.Error = err.Error()
+	default:
+		result.Status = types.FilePushed
 		if version == "" {
-			log.Infof("File %s was pushed to server.", filePath)
+			logger.Infof("File %s was pushed to server.", filePath)
 		} else {
-			log.Infof("File %s (version '%v') was pushed to server.", filePath, version)
+			logger.Infof("File %s (version '%v') was pushed to server.", filePath, version)
 		}
 	}
 }
@@ -175,30 +184,30 @@ func (f *Service) buildPushRequest(fileInfo os.FileInfo, filePath, version strin
 	contentTypeCodes map[string]struct{}, isFilepath bool) (*types.PushRequest, error) {
 	fileContent, err := ioutil.ReadFile(filePath)
 	if err != nil {
-		log.Errorf("can't handle file %s: %v", filePath, err)
+		f.logger().Errorf("can't handle file %s: %v", filePath, err)
 		return nil, err
 	}
 	dir, err := os.Getwd()
 	if err != nil {
-		log.Debugf("error occurred on getting current dir: %v", err)
+		f.logger().Debugf("error occurred on getting current dir: %v", err)
 	}
 	if len(f.Config.Push.Sources.Folders) > 0 {
 		dir = f.Config.Push.Sources.Folders[0]
 	}
 	relativeFilePath, err := filepath.Rel(dir, filePath)
 	if err != nil {
-		log.Debugf("error occurred on building relativePath: %v", err)
+		f.logger().Debugf("error occurred on building relativePath: %v", err)
 		relativeFilePath = filePath
 	}
 	if relativeFilePath == "" {
-		log.Debugf("relativeFilePath is empty. Use filePath '%s' instead", filePath)
+		f.logger().Debugf("relativeFilePath is empty. Use filePath '%s' instead", filePath)
 		relativeFilePath = filePath
 	}
 	if isFilepath && !filterFileByWorkspace(relativeFilePath, filePath, workspace) {
-		return nil, errors.New("file not pass source name")
+		return nil, errNotSourceName
 	}
 	if !filterFileByMimeType(filePath, fileInfo.Name(), contentTypeCodes) {
-		return nil, errors.New("file not pass mime type check")
+		return nil, errMimeType
 	}
 	if !isFilepath {
 		relativeFilePath = ""
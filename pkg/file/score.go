@@ -1,33 +1,38 @@
 package file
 
 import (
-	"fmt"
-	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/qordobacode/cli-v2/pkg/types"
 )
 
-var (
-	scoreGetTemplate = "%s/v3/contentscore/organizations/%d/workspaces/%d/documents/%v/personas/%d/score?documentLength=%d"
-)
-
 // FileScore function returns file score
 func (f *Service) FileScore(filename, version string) *types.ScoreResponseBody {
 	file, personaID := f.FindFile(filename, version, false)
 	if file == nil {
 		return nil
 	}
-	base := f.Config.GetAPIBase()
-	fileListURL := fmt.Sprintf(scoreGetTemplate, base, f.Config.Qordoba.OrganizationID,
-		f.Config.Qordoba.WorkspaceID, file.FileID, personaID, 1)
-	sourceResponse, err := f.QordobaClient.GetFromServer(fileListURL)
+	score, err := f.ScoreFile(file, personaID)
 	if err != nil {
+		f.logger().Errorf("%v", err)
 		return nil
 	}
-	var scoreResponseBody types.ScoreResponseBody
-	err = scoreResponseBody.UnmarshalJSON(sourceResponse)
-	if err != nil {
-		log.Errorf("error occurred on file score response unmarshalling: %v", err)
-		return nil
+	return score
+}
+
+// ScoreFile returns score of file for persona
+func (f *Service) ScoreFile(file *types.File, personaID int) (*types.ScoreResponseBody, error) {
+	return f.apiClient().Score(file.FileID, personaID, documentLength(file))
+}
+
+// documentLength is a number of words in file. Server expects at least one word
+func documentLength(file *types.File) int {
+	words := file.Counts.WordCount
+	if words == 0 {
+		for _, progress := range file.ByWorkflowProgress {
+			words += progress.Counts.WordCount
+		}
+	}
+	if words == 0 {
+		return 1
 	}
-	return &scoreResponseBody
+	return words
 }
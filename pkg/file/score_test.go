@@ -2,6 +2,9 @@ package file
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/qordobacode/cli-v2/pkg/mock"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
@@ -30,3 +33,22 @@ func TestService_FileScore(t *testing.T) {
 	service := startScore(t)
 	service.FileScore("test.json", "")
 }
+
+func TestService_ScoreFile(t *testing.T) {
+	scoreClient := mock.NewMockQordobaClient(gomock.NewController(t))
+	service := &Service{Config: &types.Config{}, QordobaClient: scoreClient}
+	scoreClient.EXPECT().GetFromServer("https://app.qordoba.com/v3/contentscore/organizations/0/workspaces/0/documents/5/personas/3/score?documentLength=42").
+		Return([]byte(`{"documentScore": 7.5, "breakdown": [{"category": "Grammar", "issueCount": 2, "score": 8, "enabled": true}]}`), nil)
+	score, err := service.ScoreFile(&types.File{FileID: 5, ByWorkflowProgress: []types.ByWorkflowProgress{
+		{Counts: types.Counts{WordCount: 40}},
+		{Counts: types.Counts{WordCount: 2}},
+	}}, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 7.5, score.DocumentScore)
+	assert.Equal(t, 2, score.Breakdown[0].IssueCount)
+}
+
+func TestDocumentLength(t *testing.T) {
+	assert.Equal(t, 1, documentLength(&types.File{}))
+	assert.Equal(t, 12, documentLength(&types.File{Counts: types.TotalCounts{WordCount: 12}}))
+}
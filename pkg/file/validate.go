@@ -0,0 +1,48 @@
+package file
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/resource"
+)
+
+// ValidateFiles checks encoding, syntax, duplicated keys, empty values and placeholders of files,
+// which pass push's black list
+func (f *Service) ValidateFiles(fileList []string) []resource.Issue {
+	return f.validateFiles(f.filterFiles(fileList))
+}
+
+func (f *Service) validateFiles(fileList []string) []resource.Issue {
+	issues := make([]resource.Issue, 0)
+	for _, filePath := range fileList {
+		if !f.Local.FileExists(filePath) {
+			continue
+		}
+		if !resource.IsSupported(filePath) {
+			f.logger().Debugf("file %s has format, which is not supported by validation. Skip", filePath)
+			continue
+		}
+		content, err := f.Local.Read(filePath)
+		if err != nil {
+			f.logger().Errorf("can't handle file %s: %v", filePath, err)
+			continue
+		}
+		issues = append(issues, resource.Validate(filePath, content)...)
+	}
+	return issues
+}
+
+// validateBeforePush prints validation issues and returns false if files can't be pushed
+func (f *Service) validateBeforePush(fileList []string) bool {
+	issues := f.validateFiles(fileList)
+	for _, issue := range issues {
+		if issue.Severity == resource.Error {
+			f.logger().Errorf("%v", issue)
+		} else {
+			f.logger().Infof("%v", issue)
+		}
+	}
+	if resource.HasErrors(issues, false) {
+		f.logger().Errorf("Push was aborted due to validation errors. Fix them or use `--skip-validation` flag")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,27 @@
+package file
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/resource"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestService_ValidateFiles(t *testing.T) {
+	service := buildFileService(t)
+	local.EXPECT().FileExists("en.json").Return(true)
+	local.EXPECT().Read("en.json").Return([]byte("{\n  \"title\": \"Hello {name\"\n}"), nil)
+	local.EXPECT().FileExists("en.docx").Return(true)
+	local.EXPECT().FileExists("notfound.json").Return(false)
+
+	issues := service.ValidateFiles([]string{"en.json", "en.docx", "notfound.json"})
+	assert.Len(t, issues, 1)
+	assert.Equal(t, resource.Error, issues[0].Severity)
+	assert.Equal(t, 2, issues[0].Line)
+}
+
+func TestService_ValidateFilesBlacklist(t *testing.T) {
+	service := buildFileService(t)
+	service.Config.Blacklist.Sources = []string{".*.json"}
+	issues := service.ValidateFiles([]string{"filesearch_response.json"})
+	assert.Empty(t, issues)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/olekukonko/tablewriter"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/output"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"io/ioutil"
 	"os"
@@ -47,8 +48,12 @@ func (l *Local) Read(path string) ([]byte, error) {
 	return bytes, err
 }
 
-// Write function store body parameter as a file locally
+// Write function store body parameter as a file locally. Missing parent directories are created
 func (l *Local) Write(fileName string, body []byte) {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		log.Errorf("error occurred on creating new directories: %v", err)
+		return
+	}
 	err := ioutil.WriteFile(fileName, body, defaultFilePerm)
 	if err != nil {
 		log.Errorf("error occurred on writing file: %v", err)
@@ -251,7 +256,7 @@ func addFilesInMap(path string, fileMap map[string]bool, isRecursive bool) {
 
 // RenderTable2Stdin takes header and data together and print out in STDOUT as a table
 func (*Local) RenderTable2Stdin(header []string, data [][]string) {
-	table := tablewriter.NewWriter(os.Stdout)
+	table := tablewriter.NewWriter(output.Stdout)
 	table.SetHeader(header)
 	table.AppendBulk(data)
 	table.Render() // Send output
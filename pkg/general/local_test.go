@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -87,6 +88,17 @@ func Test_FileExists(t *testing.T) {
 	assert.False(t, localService.FileExists("notfound.xml"))
 }
 
+func TestLocal_WriteCreatesDirectories(t *testing.T) {
+	dir, err := ioutil.TempDir("", "local")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "fr-fr", "values", "strings.xml")
+	localService.Write(fileName, []byte("<resources/>"))
+	bytes, err := ioutil.ReadFile(fileName)
+	assert.Nil(t, err)
+	assert.Equal(t, "<resources/>", string(bytes))
+}
+
 func Test_QordobaHome(t *testing.T) {
 	qordobaHome, err := localService.QordobaHome()
 	assert.Nil(t, err)
@@ -1,60 +1,255 @@
 package log
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 )
 
 // Level data type
 type Level int
 
+// log levels
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+// log formats
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
 // Level data type
 var (
 	IsVerbose = false
+
+	levelNames = map[Level]string{DebugLevel: "debug", InfoLevel: "info", WarnLevel: "warn", ErrorLevel: "error"}
+	std        = New(InfoLevel, TextFormat, os.Stderr)
+	now        = time.Now
 )
 
+// Fields are key-value pairs attached to log entry, e.g. file, persona or request id
+type Fields map[string]interface{}
+
+// Logger is a leveled logger. Services accept it, so library users can plug in their own implementation
+type Logger interface {
+	Debugf(format string, v ...interface{})
+	Infof(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Errorf(format string, v ...interface{})
+	WithFields(fields Fields) Logger
+}
+
+// String returns name of level
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// ParseLevel returns level by name
+func ParseLevel(name string) (Level, error) {
+	for level, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return level, nil
+		}
+	}
+	return InfoLevel, fmt.Errorf("invalid log level '%s'; please provide one of: debug, info, warn, error", name)
+}
+
+// sink is a destination of log entries. Entries in files have timestamp and level in text format
+type sink struct {
+	writer   io.Writer
+	withTime bool
+}
+
+type loggerConfig struct {
+	level  Level
+	format string
+	sinks  []sink
+	mutex  sync.Mutex
+}
+
+type stdLogger struct {
+	config *loggerConfig
+	fields Fields
+}
+
+// New creates logger writing entries of level and higher into writer
+func New(level Level, format string, writer io.Writer) Logger {
+	return &stdLogger{config: &loggerConfig{
+		level:  level,
+		format: format,
+		sinks:  []sink{{writer: writer}},
+	}}
+}
+
+// Configure sets default logger from `--log-level`, `--log-format` and `--log-file` flags.
+// Log file gets entries in addition to stderr
+func Configure(levelName, format, file string) error {
+	level, err := ParseLevel(levelName)
+	if err != nil {
+		return err
+	}
+	if format != TextFormat && format != JSONFormat {
+		return fmt.Errorf("invalid log format '%s'; please provide one of: text, json", format)
+	}
+	logger := New(level, format, os.Stderr).(*stdLogger)
+	if file != "" {
+		logFile, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("can't open log file: %v", err)
+		}
+		logger.config.sinks = append(logger.config.sinks, sink{writer: logFile, withTime: true})
+	}
+	std = logger
+	return nil
+}
+
+// Default returns logger used by package functions
+func Default() Logger {
+	return std
+}
+
+// OrDefault returns logger or default logger if it's nil. Services use it for their optional `Logger` field,
+// so logger set there is used instead of default one
+func OrDefault(logger Logger) Logger {
+	if logger == nil {
+		return Default()
+	}
+	return logger
+}
+
+// SetDefault replaces logger used by package functions
+func SetDefault(logger Logger) {
+	std = logger
+}
+
+// Debugf logs formatted debug info
+func (l *stdLogger) Debugf(format string, v ...interface{}) {
+	l.log(DebugLevel, format, v...)
+}
+
+// Infof logs formatted informational message
+func (l *stdLogger) Infof(format string, v ...interface{}) {
+	l.log(InfoLevel, format, v...)
+}
+
+// Warnf logs formatted warning
+func (l *stdLogger) Warnf(format string, v ...interface{}) {
+	l.log(WarnLevel, format, v...)
+}
+
+// Errorf logs formatted error message
+func (l *stdLogger) Errorf(format string, v ...interface{}) {
+	l.log(ErrorLevel, format, v...)
+}
+
+// WithFields returns logger adding fields to every entry
+func (l *stdLogger) WithFields(fields Fields) Logger {
+	merged := make(Fields, len(l.fields)+len(fields))
+	for key, value := range l.fields {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return &stdLogger{config: l.config, fields: merged}
+}
+
+func (l *stdLogger) log(level Level, format string, v ...interface{}) {
+	if level < l.config.level && !(level == DebugLevel && IsVerbose) {
+		return
+	}
+	message := format
+	if len(v) > 0 {
+		message = fmt.Sprintf(format, v...)
+	}
+	message = strings.TrimRight(message, "\n")
+	timestamp := now()
+	l.config.mutex.Lock()
+	defer l.config.mutex.Unlock()
+	for _, sink := range l.config.sinks {
+		fmt.Fprint(sink.writer, l.format(level, message, timestamp, sink.withTime))
+	}
+}
+
+func (l *stdLogger) format(level Level, message string, timestamp time.Time, withTime bool) string {
+	if l.config.format == JSONFormat {
+		entry := make(map[string]interface{}, len(l.fields)+3)
+		for key, value := range l.fields {
+			entry[key] = value
+		}
+		entry["time"] = timestamp.Format(time.RFC3339Nano)
+		entry["level"] = level.String()
+		entry["msg"] = message
+		content, err := json.Marshal(entry)
+		if err != nil {
+			content, _ = json.Marshal(map[string]string{"level": level.String(), "msg": message})
+		}
+		return string(content) + "\n"
+	}
+	var builder strings.Builder
+	if withTime {
+		builder.WriteString(timestamp.Format(time.RFC3339) + " " + strings.ToUpper(level.String()) + " ")
+	}
+	builder.WriteString(message)
+	keys := make([]string, 0, len(l.fields))
+	for key := range l.fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		builder.WriteString(fmt.Sprintf(" %s=%v", key, l.fields[key]))
+	}
+	return builder.String() + "\n"
+}
+
+// WithFields returns default logger adding fields to every entry
+func WithFields(fields Fields) Logger {
+	return std.WithFields(fields)
+}
+
 // Error logs error message
 func Error(v ...interface{}) {
-	fmt.Fprintf(os.Stderr, fmt.Sprint(v...)+"\n")
+	std.Errorf("%s", fmt.Sprint(v...))
 }
 
 // Errorf logs formatted error message
 func Errorf(format string, v ...interface{}) {
-	fmt.Fprintf(os.Stderr, fmt.Sprintf(format+"\n", v...))
+	std.Errorf(format, v...)
+}
+
+// Warnf logs formatted warning
+func Warnf(format string, v ...interface{}) {
+	std.Warnf(format, v...)
 }
 
 // Info logs informational message
 func Info(v ...interface{}) {
-	out(fmt.Sprint(v...))
+	std.Infof("%s", fmt.Sprint(v...))
 }
 
 // Infof logs formatted informational message
 func Infof(format string, v ...interface{}) {
-	out(format, v...)
+	std.Infof(format, v...)
 }
 
 // Debug logs debug info
 func Debug(v ...interface{}) {
-	if IsVerbose {
-		out(fmt.Sprint(v...))
-	}
+	std.Debugf("%s", fmt.Sprint(v...))
 }
 
 // Debugf logs formatted debug info
 func Debugf(format string, v ...interface{}) {
-	if IsVerbose {
-		out(format, v...)
-	}
-}
-
-func out(format string, v ...interface{}) {
-	if len(v) > 0 {
-		template := format + "\n"
-		fmt.Printf(template, v...)
-	} else {
-		fmt.Printf("%s\n", format)
-	}
+	std.Debugf(format, v...)
 }
 
 // TimeTrack function for printing function time in log
@@ -0,0 +1,68 @@
+package log
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoggerText(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := New(InfoLevel, TextFormat, &buffer)
+	logger.Debugf("hidden")
+	logger.WithFields(Fields{"persona": "fr-fr", "file": "en.json"}).Infof("file %s was downloaded\n", "fr.json")
+	logger.Errorf("100% failed")
+	assert.Equal(t, "file fr.json was downloaded file=en.json persona=fr-fr\n100% failed\n", buffer.String())
+
+	buffer.Reset()
+	IsVerbose = true
+	defer func() { IsVerbose = false }()
+	logger.Debugf("shown")
+	assert.Equal(t, "shown\n", buffer.String())
+}
+
+func TestLoggerJSON(t *testing.T) {
+	now = func() time.Time { return time.Date(2019, 10, 24, 10, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+	var buffer bytes.Buffer
+	logger := New(WarnLevel, JSONFormat, &buffer)
+	logger.Infof("hidden")
+	logger.WithFields(Fields{"request_id": "1-1"}).Warnf("slow request")
+	assert.Equal(t, `{"level":"warn","msg":"slow request","request_id":"1-1","time":"2019-10-24T10:00:00Z"}`+"\n", buffer.String())
+}
+
+func TestOrDefault(t *testing.T) {
+	logger := New(InfoLevel, TextFormat, ioutil.Discard)
+	assert.Equal(t, logger, OrDefault(logger))
+	assert.Equal(t, Default(), OrDefault(nil))
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("DEBUG")
+	assert.Nil(t, err)
+	assert.Equal(t, DebugLevel, level)
+	_, err = ParseLevel("trace")
+	assert.EqualError(t, err, "invalid log level 'trace'; please provide one of: debug, info, warn, error")
+}
+
+func TestConfigure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	defer SetDefault(Default())
+	now = func() time.Time { return time.Date(2019, 10, 24, 10, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	assert.NotNil(t, Configure("info", "xml", ""))
+	path := filepath.Join(dir, "qor.log")
+	assert.Nil(t, Configure("error", TextFormat, path))
+	Infof("hidden")
+	WithFields(Fields{"file": "en.json"}).Errorf("push failed")
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-10-24T10:00:00Z ERROR push failed file=en.json\n", string(content))
+}
@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"github.com/qordobacode/cli-v2/pkg/resource"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"net/http"
 )
@@ -44,18 +45,27 @@ type FileService interface {
 	WorkspaceFiles(personaID int, withProgressStatus bool) (*types.FileSearchResponse, error)
 	WorkspaceFilesWithLimit(personaID int, withProgressStatus bool, limit int) (*types.FileSearchResponse, error)
 	FindFile(fileName, version string, withProgressStatus bool) (*types.File, int)
-	DownloadFile(persona types.Person, fileName string, file *types.File)
-	DownloadSourceFile(fileName string, file *types.File, withUpdates bool)
-	PushFolder(folder, version string, isRecursive bool)
-	PushFiles(fileList []string, version string, isRecursive bool)
+	DownloadFile(persona types.Person, fileName string, file *types.File) error
+	DownloadSourceFile(fileName string, file *types.File, withUpdates bool) error
+	PushFolder(folder, version string, isRecursive bool) []*types.FileResult
+	PushFiles(fileList []string, version string, isRecursive bool) []*types.FileResult
+	ValidateFiles(fileList []string) []resource.Issue
 	DeleteFile(fileName, version string)
 	FileScore(filename, version string) *types.ScoreResponseBody
+	ScoreFile(file *types.File, personaID int) (*types.ScoreResponseBody, error)
 }
 
 // SegmentService contains all logic about Qordoba's segments
 type SegmentService interface {
 	FindSegment(fileName, fileVersion, key string) (*types.Segment, *types.File)
 	AddKey(fileName, version string, keyAddRequest *types.KeyAddRequest)
-	UpdateKey(fileName, version string, keyAddRequest *types.KeyAddRequest)
+	FindTranslations(fileName, fileVersion, key string, audiences []string) (*types.Segment, map[string]string)
+	UpdateKey(fileName, version string, keyAddRequest *types.KeyAddRequest, options *types.ValueUpdateOptions)
 	DeleteKey(fileName, version, segmentKey string)
+	AddKeys(fileName, version string, keyAddRequests []*types.KeyAddRequest) []*types.KeyResult
+	UpdateKeys(fileName, version string, keyAddRequests []*types.KeyAddRequest, options *types.ValueUpdateOptions) []*types.KeyResult
+	DeleteKeys(fileName, version string, segmentKeys []string) []*types.KeyResult
+	ListSegments(file *types.File, personaID int) ([]types.Segment, error)
+	SearchSegments(query string, isKeyPattern bool, personas []types.Person) ([]*types.SegmentMatch, error)
+	MoveSegments(fileName, version, audience string, keys []string, stepName string) ([]*types.KeyResult, error)
 }
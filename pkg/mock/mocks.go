@@ -2,6 +2,7 @@ package mock
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/qordobacode/cli-v2/pkg/resource"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"net/http"
 	"reflect"
@@ -417,9 +418,11 @@ func (mr *MockFileServiceMockRecorder) FindFile(fileName, version, withProgressS
 }
 
 // DownloadFile mocks base method
-func (m *MockFileService) DownloadFile(person types.Person, fileName string, file *types.File) {
+func (m *MockFileService) DownloadFile(person types.Person, fileName string, file *types.File) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "DownloadFile", person, fileName, file)
+	ret := m.ctrl.Call(m, "DownloadFile", person, fileName, file)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // DownloadFile indicates an expected call of DownloadFile
@@ -429,9 +432,11 @@ func (mr *MockFileServiceMockRecorder) DownloadFile(person types.Person, fileNam
 }
 
 // DownloadSourceFile mocks base method
-func (m *MockFileService) DownloadSourceFile(fileName string, file *types.File, withUpdates bool) {
+func (m *MockFileService) DownloadSourceFile(fileName string, file *types.File, withUpdates bool) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "DownloadSourceFile", fileName, file, withUpdates)
+	ret := m.ctrl.Call(m, "DownloadSourceFile", fileName, file, withUpdates)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // DownloadSourceFile indicates an expected call of DownloadSourceFile
@@ -441,9 +446,11 @@ func (mr *MockFileServiceMockRecorder) DownloadSourceFile(fileName, file, withUp
 }
 
 // PushFolder mocks base method
-func (m *MockFileService) PushFolder(folder, version string, isRecursive bool) {
+func (m *MockFileService) PushFolder(folder, version string, isRecursive bool) []*types.FileResult {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "PushFolder", folder, version, isRecursive)
+	ret := m.ctrl.Call(m, "PushFolder", folder, version, isRecursive)
+	ret0, _ := ret[0].([]*types.FileResult)
+	return ret0
 }
 
 // PushFolder indicates an expected call of PushFolder
@@ -453,9 +460,11 @@ func (mr *MockFileServiceMockRecorder) PushFolder(folder, version interface{}) *
 }
 
 // PushFiles mocks base method
-func (m *MockFileService) PushFiles(fileList []string, version string, isRecursive bool) {
+func (m *MockFileService) PushFiles(fileList []string, version string, isRecursive bool) []*types.FileResult {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "PushFiles", fileList, version, isRecursive)
+	ret := m.ctrl.Call(m, "PushFiles", fileList, version, isRecursive)
+	ret0, _ := ret[0].([]*types.FileResult)
+	return ret0
 }
 
 // PushFiles indicates an expected call of PushFiles
@@ -464,6 +473,20 @@ func (mr *MockFileServiceMockRecorder) PushFiles(fileList, version interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushFiles", reflect.TypeOf((*MockFileService)(nil).PushFiles), fileList, version)
 }
 
+// ValidateFiles mocks base method
+func (m *MockFileService) ValidateFiles(fileList []string) []resource.Issue {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateFiles", fileList)
+	ret0, _ := ret[0].([]resource.Issue)
+	return ret0
+}
+
+// ValidateFiles indicates an expected call of ValidateFiles
+func (mr *MockFileServiceMockRecorder) ValidateFiles(fileList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateFiles", reflect.TypeOf((*MockFileService)(nil).ValidateFiles), fileList)
+}
+
 // DeleteFile mocks base method
 func (m *MockFileService) DeleteFile(fileName, version string) {
 	m.ctrl.T.Helper()
@@ -490,6 +513,21 @@ func (mr *MockFileServiceMockRecorder) FileScore(filename, version interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileScore", reflect.TypeOf((*MockFileService)(nil).FileScore), filename, version)
 }
 
+// ScoreFile mocks base method
+func (m *MockFileService) ScoreFile(file *types.File, personaID int) (*types.ScoreResponseBody, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScoreFile", file, personaID)
+	ret0, _ := ret[0].(*types.ScoreResponseBody)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScoreFile indicates an expected call of ScoreFile
+func (mr *MockFileServiceMockRecorder) ScoreFile(file, personaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScoreFile", reflect.TypeOf((*MockFileService)(nil).ScoreFile), file, personaID)
+}
+
 // MockSegmentService is a mock of SegmentService interface
 type MockSegmentService struct {
 	ctrl     *gomock.Controller
@@ -542,16 +580,31 @@ func (mr *MockSegmentServiceMockRecorder) AddKey(fileName, version, keyAddReques
 
 }
 
+// FindTranslations mocks base method
+func (m *MockSegmentService) FindTranslations(fileName, fileVersion, key string, audiences []string) (*types.Segment, map[string]string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTranslations", fileName, fileVersion, key, audiences)
+	ret0, _ := ret[0].(*types.Segment)
+	ret1, _ := ret[1].(map[string]string)
+	return ret0, ret1
+}
+
+// FindTranslations indicates an expected call of FindTranslations
+func (mr *MockSegmentServiceMockRecorder) FindTranslations(fileName, fileVersion, key, audiences interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTranslations", reflect.TypeOf((*MockSegmentService)(nil).FindTranslations), fileName, fileVersion, key, audiences)
+}
+
 // UpdateKey mocks base method
-func (m *MockSegmentService) UpdateKey(fileName, version string, keyAddRequest *types.KeyAddRequest) {
+func (m *MockSegmentService) UpdateKey(fileName, version string, keyAddRequest *types.KeyAddRequest, options *types.ValueUpdateOptions) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "UpdateKey", fileName, version, keyAddRequest)
+	m.ctrl.Call(m, "UpdateKey", fileName, version, keyAddRequest, options)
 }
 
 // UpdateKey indicates an expected call of UpdateKey
-func (mr *MockSegmentServiceMockRecorder) UpdateKey(fileName, version, keyAddRequest interface{}) *gomock.Call {
+func (mr *MockSegmentServiceMockRecorder) UpdateKey(fileName, version, keyAddRequest, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKey", reflect.TypeOf((*MockSegmentService)(nil).UpdateKey), fileName, version, keyAddRequest)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKey", reflect.TypeOf((*MockSegmentService)(nil).UpdateKey), fileName, version, keyAddRequest, options)
 }
 
 // DeleteKey mocks base method
@@ -565,3 +618,90 @@ func (mr *MockSegmentServiceMockRecorder) DeleteKey(fileName, version, segmentKe
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKey", reflect.TypeOf((*MockSegmentService)(nil).DeleteKey), fileName, version, segmentKey)
 }
+
+// AddKeys mocks base method
+func (m *MockSegmentService) AddKeys(fileName, version string, keyAddRequests []*types.KeyAddRequest) []*types.KeyResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddKeys", fileName, version, keyAddRequests)
+	ret0, _ := ret[0].([]*types.KeyResult)
+	return ret0
+}
+
+// AddKeys indicates an expected call of AddKeys
+func (mr *MockSegmentServiceMockRecorder) AddKeys(fileName, version, keyAddRequests interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddKeys", reflect.TypeOf((*MockSegmentService)(nil).AddKeys), fileName, version, keyAddRequests)
+}
+
+// UpdateKeys mocks base method
+func (m *MockSegmentService) UpdateKeys(fileName, version string, keyAddRequests []*types.KeyAddRequest, options *types.ValueUpdateOptions) []*types.KeyResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateKeys", fileName, version, keyAddRequests, options)
+	ret0, _ := ret[0].([]*types.KeyResult)
+	return ret0
+}
+
+// UpdateKeys indicates an expected call of UpdateKeys
+func (mr *MockSegmentServiceMockRecorder) UpdateKeys(fileName, version, keyAddRequests, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKeys", reflect.TypeOf((*MockSegmentService)(nil).UpdateKeys), fileName, version, keyAddRequests, options)
+}
+
+// DeleteKeys mocks base method
+func (m *MockSegmentService) DeleteKeys(fileName, version string, segmentKeys []string) []*types.KeyResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKeys", fileName, version, segmentKeys)
+	ret0, _ := ret[0].([]*types.KeyResult)
+	return ret0
+}
+
+// DeleteKeys indicates an expected call of DeleteKeys
+func (mr *MockSegmentServiceMockRecorder) DeleteKeys(fileName, version, segmentKeys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKeys", reflect.TypeOf((*MockSegmentService)(nil).DeleteKeys), fileName, version, segmentKeys)
+}
+
+// ListSegments mocks base method
+func (m *MockSegmentService) ListSegments(file *types.File, personaID int) ([]types.Segment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSegments", file, personaID)
+	ret0, _ := ret[0].([]types.Segment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSegments indicates an expected call of ListSegments
+func (mr *MockSegmentServiceMockRecorder) ListSegments(file, personaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSegments", reflect.TypeOf((*MockSegmentService)(nil).ListSegments), file, personaID)
+}
+
+// SearchSegments mocks base method
+func (m *MockSegmentService) SearchSegments(query string, isKeyPattern bool, personas []types.Person) ([]*types.SegmentMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSegments", query, isKeyPattern, personas)
+	ret0, _ := ret[0].([]*types.SegmentMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSegments indicates an expected call of SearchSegments
+func (mr *MockSegmentServiceMockRecorder) SearchSegments(query, isKeyPattern, personas interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSegments", reflect.TypeOf((*MockSegmentService)(nil).SearchSegments), query, isKeyPattern, personas)
+}
+
+// MoveSegments mocks base method
+func (m *MockSegmentService) MoveSegments(fileName, version, audience string, keys []string, stepName string) ([]*types.KeyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveSegments", fileName, version, audience, keys, stepName)
+	ret0, _ := ret[0].([]*types.KeyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveSegments indicates an expected call of MoveSegments
+func (mr *MockSegmentServiceMockRecorder) MoveSegments(fileName, version, audience, keys, stepName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveSegments", reflect.TypeOf((*MockSegmentService)(nil).MoveSegments), fileName, version, audience, keys, stepName)
+}
@@ -0,0 +1,488 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"github.com/qordobacode/cli-v2/pkg/resource"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const serverKeySeparator = "/"
+
+// listWorkspaces handles `GET /v3/organizations/{organization}/workspaces`
+func (s *Server) listWorkspaces(request *http.Request, ids []int) (interface{}, error) {
+	response := &types.WorkspaceResponse{Workspaces: make([]types.WorkspaceData, 0)}
+	response.Meta.Paging = types.Paging{TotalEnabled: 1, TotalResults: 1}
+	if queryInt(request, "offset", 0) == 0 {
+		s.Store.read(func(state *State) {
+			response.Workspaces = append(response.Workspaces, state.Workspace)
+		})
+	}
+	return response, nil
+}
+
+// listFiles handles `GET .../personas/{persona}/files`. Files are filtered by `filename` and `version` if set
+func (s *Server) listFiles(request *http.Request, ids []int) (interface{}, error) {
+	personaID := ids[2]
+	query := request.URL.Query()
+	withProgressStatus := query.Get("withProgressStatus") == "true"
+	limit := queryInt(request, "limit", 0)
+	response := &types.FileSearchResponse{Files: make([]types.File, 0)}
+	var err error
+	s.Store.read(func(state *State) {
+		if !state.hasPersona(personaID) {
+			err = errorf(http.StatusNotFound, "persona %d not found", personaID)
+			return
+		}
+		for _, file := range state.Files {
+			if _, ok := query["filename"]; ok && (file.Name != query.Get("filename") || file.Version != query.Get("version")) {
+				continue
+			}
+			if limit > 0 && len(response.Files) == limit {
+				break
+			}
+			view := state.fileView(file, personaID, withProgressStatus)
+			response.Files = append(response.Files, view)
+			response.TotalCounts.SegmentCount += view.Counts.SegmentCount
+			response.TotalCounts.WordCount += view.Counts.WordCount
+		}
+	})
+	response.Meta.Paging = types.Paging{TotalEnabled: len(response.Files), TotalResults: len(response.Files)}
+	return response, err
+}
+
+// fileView returns file how server describes it for persona
+func (state *State) fileView(file *File, personaID int, withProgressStatus bool) types.File {
+	view := types.File{
+		FileID:    file.ID,
+		Enabled:   true,
+		Completed: true,
+		Filename:  file.Name,
+		Filepath:  file.Filepath,
+		Version:   file.Version,
+		Tags:      make([]types.Tags, 0),
+		Update:    file.UpdatedAt,
+		CreatedAt: file.CreatedAt,
+	}
+	progress := make(map[int]*types.Counts)
+	for _, segment := range file.Segments {
+		words := len(strings.Fields(segment.Source))
+		view.Counts.SegmentCount++
+		view.Counts.WordCount += words
+		step := state.step(segment, personaID)
+		view.Completed = view.Completed && step.Complete
+		if progress[step.ID] == nil {
+			progress[step.ID] = &types.Counts{}
+		}
+		progress[step.ID].SegmentCount++
+		progress[step.ID].WordCount += words
+	}
+	if withProgressStatus {
+		view.ByWorkflowProgress = make([]types.ByWorkflowProgress, 0, len(state.Workspace.Workflow))
+		for _, workflow := range state.Workspace.Workflow {
+			counts := types.Counts{}
+			if progress[workflow.ID] != nil {
+				counts = *progress[workflow.ID]
+			}
+			view.ByWorkflowProgress = append(view.ByWorkflowProgress, types.ByWorkflowProgress{Workflow: workflow, Counts: counts})
+		}
+	}
+	return view
+}
+
+// upsertFile handles `POST /v3/files/organizations/{organization}/workspaces/{workspace}/upsert`. File with the same
+// name and version is replaced, translations and workflow steps of its unchanged keys are kept
+func (s *Server) upsertFile(request *http.Request, ids []int) (interface{}, error) {
+	var pushRequest types.PushRequest
+	if err := decodeBody(request, &pushRequest); err != nil {
+		return nil, err
+	}
+	if pushRequest.FileName == "" {
+		return nil, errorf(http.StatusBadRequest, "filename is required")
+	}
+	entries := make([]resource.Entry, 0)
+	if resource.IsSupported(pushRequest.FileName) {
+		parsed, err := resource.Parse(pushRequest.FileName, []byte(pushRequest.Content))
+		if err != nil {
+			return nil, errorf(http.StatusBadRequest, "can't parse %s: %v", pushRequest.FileName, err)
+		}
+		entries = parsed.Entries
+	}
+	var fileID int
+	err := s.Store.update(func(state *State) error {
+		now := s.nowMillis()
+		var file *File
+		for _, existing := range state.Files {
+			if existing.Name == pushRequest.FileName && existing.Version == pushRequest.Version {
+				file = existing
+			}
+		}
+		if file == nil {
+			file = &File{ID: state.nextID(), Name: pushRequest.FileName, Version: pushRequest.Version, CreatedAt: now}
+			state.Files = append(state.Files, file)
+		}
+		file.Filepath = pushRequest.Filepath
+		file.Content = pushRequest.Content
+		file.UpdatedAt = now
+		segments := make([]*Segment, 0, len(entries))
+		for _, entry := range entries {
+			key := serverKey(entry.Key)
+			segment := file.segmentByKey(key)
+			if segment == nil {
+				segment = newSegment(state.nextID(), key, entry.Value, now)
+			}
+			if segment.Source != entry.Value {
+				segment.Source, segment.SavedAt = entry.Value, now
+			}
+			segments = append(segments, segment)
+		}
+		file.Segments = segments
+		fileID = file.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"success": true, "fileId": fileID}, nil
+}
+
+// downloadFile handles `GET .../personas/{persona}/files/{file}/download`. Keys without translation keep source text
+func (s *Server) downloadFile(request *http.Request, ids []int) (interface{}, error) {
+	personaID, fileID := ids[2], ids[3]
+	var content []byte
+	var err error
+	s.Store.read(func(state *State) {
+		file := state.file(fileID)
+		if file == nil {
+			err = errorf(http.StatusNotFound, "file %d not found", fileID)
+			return
+		}
+		if !state.hasPersona(personaID) {
+			err = errorf(http.StatusNotFound, "persona %d not found", personaID)
+			return
+		}
+		translations := make(map[string]string)
+		for _, segment := range file.Segments {
+			if target, ok := segment.Targets[personaID]; ok && target != "" {
+				translations[entryKey(segment.Key)] = target
+			}
+		}
+		content = translate(file, translations)
+	})
+	return content, err
+}
+
+// downloadSourceFile handles `GET .../files/{file}/download/source`. Updated source texts are applied with `withUpdates=true`
+func (s *Server) downloadSourceFile(request *http.Request, ids []int) (interface{}, error) {
+	fileID := ids[2]
+	withUpdates := request.URL.Query().Get("withUpdates") == "true"
+	var content []byte
+	var err error
+	s.Store.read(func(state *State) {
+		file := state.file(fileID)
+		if file == nil {
+			err = errorf(http.StatusNotFound, "file %d not found", fileID)
+			return
+		}
+		if !withUpdates {
+			content = []byte(file.Content)
+			return
+		}
+		sources := make(map[string]string)
+		for _, segment := range file.Segments {
+			sources[entryKey(segment.Key)] = segment.Source
+		}
+		content = translate(file, sources)
+	})
+	return content, err
+}
+
+// translate returns file content with replaced values. Content of files which can't be parsed is returned as is
+func translate(file *File, translations map[string]string) []byte {
+	parsed, err := resource.Parse(file.Name, []byte(file.Content))
+	if err != nil {
+		return []byte(file.Content)
+	}
+	content, err := parsed.Translate(translations)
+	if err != nil {
+		return []byte(file.Content)
+	}
+	return content
+}
+
+// deleteFile handles `DELETE .../files/{file}`
+func (s *Server) deleteFile(request *http.Request, ids []int) (interface{}, error) {
+	fileID := ids[2]
+	err := s.Store.update(func(state *State) error {
+		for i, file := range state.Files {
+			if file.ID == fileID {
+				state.Files = append(state.Files[:i], state.Files[i+1:]...)
+				return nil
+			}
+		}
+		return errorf(http.StatusNotFound, "file %d not found", fileID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.FileDeleteResponse{Success: true}, nil
+}
+
+// listSegments handles `GET .../personas/{persona}/files/{file}/workflow/{workflow}/segments` with `limit`, `offset`
+// and `search` (case insensitive match of key or source text)
+func (s *Server) listSegments(request *http.Request, ids []int) (interface{}, error) {
+	personaID, fileID, workflowID := ids[2], ids[3], ids[4]
+	search := strings.ToLower(request.URL.Query().Get("search"))
+	limit, offset := queryInt(request, "limit", 0), queryInt(request, "offset", 0)
+	response := &types.SegmentSearchResponse{Segments: make([]types.Segment, 0)}
+	var err error
+	s.Store.read(func(state *State) {
+		file := state.file(fileID)
+		if file == nil {
+			err = errorf(http.StatusNotFound, "file %d not found", fileID)
+			return
+		}
+		found := 0
+		for _, segment := range file.Segments {
+			if state.step(segment, personaID).ID != workflowID {
+				continue
+			}
+			if search != "" && !strings.Contains(strings.ToLower(segment.Key), search) &&
+				!strings.Contains(strings.ToLower(segment.Source), search) {
+				continue
+			}
+			found++
+			if found <= offset || (limit > 0 && len(response.Segments) == limit) {
+				continue
+			}
+			response.Segments = append(response.Segments, types.Segment{
+				SegmentID: segment.ID,
+				StringKey: segment.Key,
+				Segment:   segment.Source,
+				SsText:    segment.Source,
+				Reference: segment.Reference,
+				Target:    segment.Targets[personaID],
+				LastSaved: int(segment.SavedAt),
+			})
+		}
+		response.Meta.Paging = types.Paging{TotalEnabled: found, TotalResults: found}
+	})
+	return response, err
+}
+
+// keyAdd handles `POST .../files/{file}/segments/keyAdd`. Existing key is rejected with 406 status
+func (s *Server) keyAdd(request *http.Request, ids []int) (interface{}, error) {
+	fileID := ids[2]
+	var keyAddRequest types.KeyAddRequest
+	if err := decodeBody(request, &keyAddRequest); err != nil {
+		return nil, err
+	}
+	key := serverKeySeparator + strings.TrimPrefix(keyAddRequest.Key, serverKeySeparator)
+	err := s.Store.update(func(state *State) error {
+		file := state.file(fileID)
+		if file == nil {
+			return errorf(http.StatusNotFound, "file %d not found", fileID)
+		}
+		if file.segmentByKey(key) != nil {
+			return errorf(http.StatusNotAcceptable, "key %s already exists", key)
+		}
+		segment := newSegment(state.nextID(), key, keyAddRequest.Source, s.nowMillis())
+		segment.Reference = keyAddRequest.Reference
+		file.Segments = append(file.Segments, segment)
+		if parsed, err := resource.Parse(file.Name, []byte(file.Content)); err == nil {
+			if content, err := parsed.Add([]resource.Entry{{Key: entryKey(key), Value: keyAddRequest.Source}}); err == nil {
+				file.Content = string(content)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"success": true}, nil
+}
+
+// sourceUpdate handles `PUT .../personas/{persona}/files/{file}/segments/{segment}/sourceUpdate`
+func (s *Server) sourceUpdate(request *http.Request, ids []int) (interface{}, error) {
+	return s.updateSegment(request, ids[3], ids[4], func(segment *Segment, updateRequest *types.ValueKeyUpdateRequest) {
+		segment.Source = updateRequest.Segment
+		if updateRequest.MoveToFirstStep {
+			segment.Steps = make(map[int]int)
+		}
+	})
+}
+
+// targetUpdate handles `PUT .../personas/{persona}/files/{file}/segments/{segment}/targetUpdate`
+func (s *Server) targetUpdate(request *http.Request, ids []int) (interface{}, error) {
+	personaID := ids[2]
+	return s.updateSegment(request, ids[3], ids[4], func(segment *Segment, updateRequest *types.ValueKeyUpdateRequest) {
+		segment.Targets[personaID] = updateRequest.Segment
+		if updateRequest.MoveToFirstStep {
+			delete(segment.Steps, personaID)
+		}
+	})
+}
+
+func (s *Server) updateSegment(request *http.Request, fileID, segmentID int, update func(*Segment, *types.ValueKeyUpdateRequest)) (interface{}, error) {
+	var updateRequest types.ValueKeyUpdateRequest
+	if err := decodeBody(request, &updateRequest); err != nil {
+		return nil, err
+	}
+	err := s.Store.update(func(state *State) error {
+		segment, err := state.findSegment(fileID, segmentID)
+		if err != nil {
+			return err
+		}
+		update(segment, &updateRequest)
+		segment.SavedAt = s.nowMillis()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"success": true}, nil
+}
+
+// keyDelete handles `DELETE .../files/{file}/segments/{segment}/keyDelete`
+func (s *Server) keyDelete(request *http.Request, ids []int) (interface{}, error) {
+	fileID, segmentID := ids[2], ids[3]
+	err := s.Store.update(func(state *State) error {
+		file := state.file(fileID)
+		if file == nil {
+			return errorf(http.StatusNotFound, "file %d not found", fileID)
+		}
+		for i, segment := range file.Segments {
+			if segment.ID == segmentID {
+				file.Segments = append(file.Segments[:i], file.Segments[i+1:]...)
+				return nil
+			}
+		}
+		return errorf(http.StatusNotFound, "segment %d not found", segmentID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"success": true}, nil
+}
+
+// workflowMove handles `POST .../personas/{persona}/files/{file}/segments/workflow/move`
+func (s *Server) workflowMove(request *http.Request, ids []int) (interface{}, error) {
+	personaID, fileID := ids[2], ids[3]
+	var moveRequest types.WorkflowMoveRequest
+	if err := decodeBody(request, &moveRequest); err != nil {
+		return nil, err
+	}
+	err := s.Store.update(func(state *State) error {
+		found := false
+		for _, workflow := range state.Workspace.Workflow {
+			found = found || workflow.ID == moveRequest.WorkflowID
+		}
+		if !found {
+			return errorf(http.StatusBadRequest, "workflow step %d not found", moveRequest.WorkflowID)
+		}
+		for _, segmentID := range moveRequest.SegmentIDs {
+			segment, err := state.findSegment(fileID, segmentID)
+			if err != nil {
+				return err
+			}
+			segment.Steps[personaID] = moveRequest.WorkflowID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"success": true}, nil
+}
+
+// score handles `GET /v3/contentscore/.../documents/{file}/personas/{persona}/score`. Score is a share of translated
+// segments on a scale of 10
+func (s *Server) score(request *http.Request, ids []int) (interface{}, error) {
+	fileID, personaID := ids[2], ids[3]
+	var response *types.ScoreResponseBody
+	var err error
+	s.Store.read(func(state *State) {
+		file := state.file(fileID)
+		if file == nil {
+			err = errorf(http.StatusNotFound, "file %d not found", fileID)
+			return
+		}
+		translated := 0
+		for _, segment := range file.Segments {
+			if segment.Targets[personaID] != "" {
+				translated++
+			}
+		}
+		score := 10.0
+		if len(file.Segments) > 0 {
+			score = math.Round(100*float64(translated)/float64(len(file.Segments))) / 10
+		}
+		response = &types.ScoreResponseBody{
+			SnapshotTime:  file.UpdatedAt,
+			DocumentScore: score,
+			Breakdown: []types.Breakdown{
+				{Category: "Translation", IssueCount: len(file.Segments) - translated, Score: score, Enabled: true},
+			},
+		}
+	})
+	return response, err
+}
+
+func (state *State) findSegment(fileID, segmentID int) (*Segment, error) {
+	file := state.file(fileID)
+	if file == nil {
+		return nil, errorf(http.StatusNotFound, "file %d not found", fileID)
+	}
+	segment := file.segment(segmentID)
+	if segment == nil {
+		return nil, errorf(http.StatusNotFound, "segment %d not found", segmentID)
+	}
+	if segment.Targets == nil {
+		segment.Targets = make(map[int]string)
+	}
+	if segment.Steps == nil {
+		segment.Steps = make(map[int]int)
+	}
+	return segment, nil
+}
+
+func newSegment(id int, key, source string, savedAt int64) *Segment {
+	return &Segment{ID: id, Key: key, Source: source, SavedAt: savedAt, Targets: make(map[int]string), Steps: make(map[int]int)}
+}
+
+func (s *Server) nowMillis() int64 {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	return now().UnixNano() / int64(time.Millisecond)
+}
+
+// serverKey converts key of resource file entry (`a.b`) into server's key (`/a/b`)
+func serverKey(key string) string {
+	return serverKeySeparator + strings.ReplaceAll(key, ".", serverKeySeparator)
+}
+
+// entryKey converts server's key into key of resource file entry
+func entryKey(key string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(key, serverKeySeparator), serverKeySeparator, ".")
+}
+
+func decodeBody(request *http.Request, v interface{}) error {
+	if err := json.NewDecoder(request.Body).Decode(v); err != nil {
+		return errorf(http.StatusBadRequest, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+func queryInt(request *http.Request, name string, defaultValue int) int {
+	value, err := strconv.Atoi(request.URL.Query().Get(name))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
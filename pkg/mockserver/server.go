@@ -0,0 +1,150 @@
+// Package mockserver implements v3 endpoints of Qordoba API used by CLI on top of in-memory or on-disk store,
+// so CLI might be run end-to-end without network access
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const workspacePath = `^/v3/organizations/(\d+)/workspaces/(\d+)`
+
+// errorResponse is a body of unsuccessful response
+type errorResponse struct {
+	ErrMessage string `json:"errMessage"`
+}
+
+// statusError is an error with HTTP status of response
+type statusError struct {
+	status  int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return e.message
+}
+
+func errorf(status int, format string, v ...interface{}) error {
+	return &statusError{status: status, message: fmt.Sprintf(format, v...)}
+}
+
+// handler handles request with IDs from request path
+type handler func(request *http.Request, ids []int) (interface{}, error)
+
+type route struct {
+	method  string
+	pattern *regexp.Regexp
+	handle  handler
+}
+
+func newRoute(method, pattern string, handle handler) route {
+	return route{method: method, pattern: regexp.MustCompile(pattern), handle: handle}
+}
+
+// Server is a mock of Qordoba API
+type Server struct {
+	Store *Store
+	// Token is expected value of `x-auth-token` header. Any token is accepted if empty
+	Token string
+	// Logger logs served requests at debug level
+	Logger log.Logger
+	// Now returns time of file and segment updates. time.Now is used if nil
+	Now func() time.Time
+
+	routes []route
+}
+
+// New creates mock server with store
+func New(store *Store, token string) *Server {
+	s := &Server{Store: store, Token: token}
+	s.routes = []route{
+		newRoute(http.MethodGet, `^/v3/organizations/(\d+)/workspaces$`, s.listWorkspaces),
+		newRoute(http.MethodGet, workspacePath+`/personas/(\d+)/files$`, s.listFiles),
+		newRoute(http.MethodPost, `^/v3/files/organizations/(\d+)/workspaces/(\d+)/upsert$`, s.upsertFile),
+		newRoute(http.MethodGet, workspacePath+`/personas/(\d+)/files/(\d+)/download$`, s.downloadFile),
+		newRoute(http.MethodGet, workspacePath+`/files/(\d+)/download/source$`, s.downloadSourceFile),
+		newRoute(http.MethodDelete, workspacePath+`/files/(\d+)$`, s.deleteFile),
+		newRoute(http.MethodGet, workspacePath+`/personas/(\d+)/files/(\d+)/workflow/(\d+)/segments$`, s.listSegments),
+		newRoute(http.MethodPost, workspacePath+`/files/(\d+)/segments/keyAdd$`, s.keyAdd),
+		newRoute(http.MethodPut, workspacePath+`/personas/(\d+)/files/(\d+)/segments/(\d+)/sourceUpdate$`, s.sourceUpdate),
+		newRoute(http.MethodPut, workspacePath+`/personas/(\d+)/files/(\d+)/segments/(\d+)/targetUpdate$`, s.targetUpdate),
+		newRoute(http.MethodDelete, workspacePath+`/files/(\d+)/segments/(\d+)/keyDelete$`, s.keyDelete),
+		newRoute(http.MethodPost, workspacePath+`/personas/(\d+)/files/(\d+)/segments/workflow/move$`, s.workflowMove),
+		newRoute(http.MethodGet, `^/v3/contentscore/organizations/(\d+)/workspaces/(\d+)/documents/(\d+)/personas/(\d+)/score$`, s.score),
+	}
+	return s
+}
+
+func (s *Server) logger() log.Logger {
+	return log.OrDefault(s.Logger)
+}
+
+// ServeHTTP finds route of request and writes JSON result of its handler
+func (s *Server) ServeHTTP(rw http.ResponseWriter, request *http.Request) {
+	result, err := s.handle(request)
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusInternalServerError
+		if statusErr, ok := err.(*statusError); ok {
+			status = statusErr.status
+		}
+		result = &errorResponse{ErrMessage: err.Error()}
+	}
+	s.logger().Debugf("mock server: %s %s: %d", request.Method, request.URL.Path, status)
+	if content, ok := result.([]byte); ok {
+		rw.Header().Set("Content-Type", "application/octet-stream")
+		rw.WriteHeader(status)
+		rw.Write(content)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	json.NewEncoder(rw).Encode(result)
+}
+
+func (s *Server) handle(request *http.Request) (interface{}, error) {
+	if s.Token != "" && request.Header.Get("x-auth-token") != s.Token {
+		return nil, errorf(http.StatusUnauthorized, "invalid x-auth-token")
+	}
+	pathFound := false
+	for _, route := range s.routes {
+		match := route.pattern.FindStringSubmatch(request.URL.Path)
+		if match == nil {
+			continue
+		}
+		pathFound = true
+		if route.method != request.Method {
+			continue
+		}
+		ids := make([]int, 0, len(match)-1)
+		for _, group := range match[1:] {
+			id, err := strconv.Atoi(group)
+			if err != nil {
+				return nil, errorf(http.StatusBadRequest, "invalid id %s", group)
+			}
+			ids = append(ids, id)
+		}
+		// organization is not checked. Ids of every route except of workspace list start with organization and workspace
+		if len(ids) > 1 && ids[1] != s.workspaceID() {
+			return nil, errorf(http.StatusNotFound, "workspace %d not found", ids[1])
+		}
+		return route.handle(request, ids)
+	}
+	if pathFound {
+		return nil, errorf(http.StatusMethodNotAllowed, "method %s is not allowed", request.Method)
+	}
+	return nil, errorf(http.StatusNotFound, "%s not found", request.URL.Path)
+}
+
+func (s *Server) workspaceID() int {
+	var id int
+	s.Store.read(func(state *State) {
+		id = state.Workspace.Workspace.ID
+	})
+	return id
+}
@@ -0,0 +1,117 @@
+package mockserver
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/api"
+	"github.com/qordobacode/cli-v2/pkg/rest"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sourceContent = `{"title":"Hello","menu":{"open":"Open"}}`
+
+func buildAPIClient(t *testing.T, store *Store) (*api.Client, func()) {
+	server := httptest.NewServer(New(store, "secret"))
+	config := &types.Config{BaseURL: server.URL}
+	config.Qordoba.AccessToken = "secret"
+	config.Qordoba.OrganizationID = OrganizationID
+	config.Qordoba.WorkspaceID = WorkspaceID
+	client, err := rest.NewClient(config)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	client.Limiter = nil
+	return &api.Client{Config: config, QordobaClient: client}, server.Close
+}
+
+func pushSource(t *testing.T, client *api.Client) int {
+	err := client.UpsertFile(&types.PushRequest{FileName: "en-us.json", Content: sourceContent, Filepath: "i18n/en-us.json"})
+	assert.Nil(t, err)
+	files, err := client.ListFiles(2, api.FileQuery{Filename: "en-us.json"})
+	if !assert.Nil(t, err) || !assert.Len(t, files.Files, 1) {
+		t.FailNow()
+	}
+	return files.Files[0].FileID
+}
+
+func TestServer_PushTranslateDownload(t *testing.T) {
+	store, _ := NewStore("")
+	client, closeServer := buildAPIClient(t, store)
+	defer closeServer()
+	fileID := pushSource(t, client)
+
+	segments, err := client.ListSegments(2, fileID, 1, api.SegmentQuery{Search: "menu"})
+	assert.Nil(t, err)
+	if !assert.Len(t, segments.Segments, 1) {
+		return
+	}
+	segment := segments.Segments[0]
+	assert.Equal(t, "/menu/open", segment.StringKey)
+	assert.Equal(t, "Open", segment.Segment)
+
+	assert.Nil(t, client.TargetUpdate(2, fileID, segment.SegmentID, &types.ValueKeyUpdateRequest{Segment: "Ouvrir"}))
+	content, err := client.DownloadFile(2, fileID)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"title":"Hello","menu":{"open":"Ouvrir"}}`, string(content))
+
+	assert.Nil(t, client.WorkflowMove(2, fileID, &types.WorkflowMoveRequest{SegmentIDs: []int{segment.SegmentID}, WorkflowID: 3}))
+	segments, err = client.ListSegments(2, fileID, 3, api.SegmentQuery{})
+	assert.Nil(t, err)
+	assert.Len(t, segments.Segments, 1)
+}
+
+func TestServer_KeyAddAndDelete(t *testing.T) {
+	store, _ := NewStore("")
+	client, closeServer := buildAPIClient(t, store)
+	defer closeServer()
+	fileID := pushSource(t, client)
+
+	assert.Nil(t, client.KeyAdd(fileID, &types.KeyAddRequest{Key: "/menu/close", Source: "Close"}))
+	err := client.KeyAdd(fileID, &types.KeyAddRequest{Key: "/menu/close", Source: "Close"})
+	assert.Equal(t, http.StatusNotAcceptable, api.StatusCode(err))
+
+	content, err := client.DownloadSourceFile(fileID, true)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"title":"Hello","menu":{"open":"Open",
+    "close": "Close"}}`, string(content))
+
+	_, err = client.DeleteFile(fileID)
+	assert.Nil(t, err)
+	_, err = client.DownloadFile(2, fileID)
+	assert.Equal(t, http.StatusNotFound, api.StatusCode(err))
+}
+
+func TestServer_InvalidToken(t *testing.T) {
+	store, _ := NewStore("")
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/v3/organizations/1/workspaces", nil)
+	request.Header.Set("x-auth-token", "invalid")
+	New(store, "secret").ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.JSONEq(t, `{"errMessage":"invalid x-auth-token"}`, recorder.Body.String())
+}
+
+func TestStore_Persistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mockserver")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state.json")
+
+	store, err := NewStore(path)
+	assert.Nil(t, err)
+	client, closeServer := buildAPIClient(t, store)
+	fileID := pushSource(t, client)
+	closeServer()
+
+	reloaded, err := NewStore(path)
+	assert.Nil(t, err)
+	state := reloaded.State()
+	assert.Len(t, state.Files, 1)
+	assert.Equal(t, fileID, state.Files[0].ID)
+	assert.Len(t, state.Files[0].Segments, 2)
+}
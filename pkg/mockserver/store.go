@@ -0,0 +1,210 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// default identifiers of mock organization and workspace
+const (
+	OrganizationID = 1
+	WorkspaceID    = 1
+)
+
+// State is a content of mock server: workspace with personas and workflow, files and their segments
+type State struct {
+	Workspace types.WorkspaceData `json:"workspace"`
+	Files     []*File             `json:"files"`
+	// LastID is the last identifier given to file or segment
+	LastID int `json:"lastId"`
+}
+
+// File is a pushed file
+type File struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	Filepath  string     `json:"filepath"`
+	Version   string     `json:"version"`
+	Content   string     `json:"content"`
+	CreatedAt int64      `json:"createdAt"`
+	UpdatedAt int64      `json:"updatedAt"`
+	Segments  []*Segment `json:"segments"`
+}
+
+// Segment is a key of file with source text. Translations and workflow steps are stored by persona ID
+type Segment struct {
+	ID        int    `json:"id"`
+	Key       string `json:"key"`
+	Source    string `json:"source"`
+	Reference string `json:"reference"`
+	// SavedAt is a time of the last update in milliseconds
+	SavedAt int64 `json:"savedAt"`
+	// Targets are translations by persona ID
+	Targets map[int]string `json:"targets"`
+	// Steps are workflow step IDs by persona ID. Segment without step is in the first step
+	Steps map[int]int `json:"steps"`
+}
+
+// DefaultState returns workspace with `en-us` source persona, `fr-fr` and `de-de` target personas
+// and Translation, Review and Published workflow steps
+func DefaultState() *State {
+	state := &State{Files: make([]*File, 0)}
+	workspace := &state.Workspace.Workspace
+	workspace.ID = WorkspaceID
+	workspace.OrganizationID = OrganizationID
+	workspace.Name = "Mock workspace"
+	workspace.SourcePersona = types.Person{ID: 1, Code: "en-us", Name: "English - United States", Direction: "ltr"}
+	workspace.TargetPersonas = []types.Person{
+		{ID: 2, Code: "fr-fr", Name: "French - France", Direction: "ltr"},
+		{ID: 3, Code: "de-de", Name: "German - Germany", Direction: "ltr"},
+	}
+	workspace.ContentTypeCodes = []types.ExtensionDescription{
+		{Name: "JSON", Extensions: []string{"json"}},
+		{Name: "YAML", Extensions: []string{"yaml", "yml"}},
+		{Name: "Android XML", Extensions: []string{"xml"}},
+		{Name: "RESX", Extensions: []string{"resx"}},
+		{Name: "Java properties", Extensions: []string{"properties"}},
+		{Name: "iOS strings", Extensions: []string{"strings"}},
+	}
+	state.Workspace.Workflow = []types.Workflow{
+		{ID: 1, Name: "Translation", Order: 0},
+		{ID: 2, Name: "Review", Order: 1},
+		{ID: 3, Name: "Published", Order: 2, Complete: true},
+	}
+	return state
+}
+
+// Store keeps state in memory. State is loaded from and saved into file if path is set
+type Store struct {
+	path  string
+	mutex sync.Mutex
+	state *State
+}
+
+// NewStore creates store with default state or state from existing file
+func NewStore(path string) (*Store, error) {
+	store := &Store{path: path, state: DefaultState()}
+	if path == "" {
+		return store, nil
+	}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, store.save()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(content, store.state); err != nil {
+		return nil, fmt.Errorf("invalid mock server state in %s: %v", path, err)
+	}
+	return store, nil
+}
+
+// State returns a copy of current state
+func (s *Store) State() *State {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	content, _ := json.Marshal(s.state)
+	state := &State{}
+	_ = json.Unmarshal(content, state)
+	return state
+}
+
+// read calls function with locked state
+func (s *Store) read(read func(state *State)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	read(s.state)
+}
+
+// update calls function with locked state and saves state if function succeeds
+func (s *Store) update(update func(state *State) error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := update(s.state); err != nil {
+		return err
+	}
+	return s.save()
+}
+
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+	content, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(s.path, content, 0644)
+}
+
+func (state *State) nextID() int {
+	state.LastID++
+	return state.LastID
+}
+
+func (state *State) file(id int) *File {
+	for _, file := range state.Files {
+		if file.ID == id {
+			return file
+		}
+	}
+	return nil
+}
+
+func (file *File) segment(id int) *Segment {
+	for _, segment := range file.Segments {
+		if segment.ID == id {
+			return segment
+		}
+	}
+	return nil
+}
+
+func (file *File) segmentByKey(key string) *Segment {
+	for _, segment := range file.Segments {
+		if segment.Key == key {
+			return segment
+		}
+	}
+	return nil
+}
+
+// step returns workflow step of segment for persona
+func (state *State) step(segment *Segment, personaID int) types.Workflow {
+	workflows := state.Workspace.Workflow
+	if stepID, ok := segment.Steps[personaID]; ok {
+		for _, workflow := range workflows {
+			if workflow.ID == stepID {
+				return workflow
+			}
+		}
+	}
+	if len(workflows) == 0 {
+		return types.Workflow{}
+	}
+	first := workflows[0]
+	for _, workflow := range workflows {
+		if workflow.Order < first.Order {
+			first = workflow
+		}
+	}
+	return first
+}
+
+func (state *State) hasPersona(personaID int) bool {
+	for _, persona := range state.Workspace.Workspace.TargetPersonas {
+		if persona.ID == personaID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v2"
+)
+
+// Format of command result
+type Format string
+
+// supported output formats
+const (
+	Table    Format = "table"
+	JSON     Format = "json"
+	YAML     Format = "yaml"
+	CSV      Format = "csv"
+	Markdown Format = "markdown"
+	Template Format = "template"
+)
+
+var (
+	// Selected is a value of global `--output` flag
+	Selected = string(Table)
+	// TemplateText is a value of global `--template` flag. Template output is used when it's set
+	TemplateText string
+	// IsJSON is a value of global `--json` flag, a shortcut for `--output json`
+	IsJSON bool
+	// Stdout is a writer for command results. Diagnostics are written into stderr by log package
+	Stdout io.Writer = os.Stdout
+
+	formats = []Format{Table, JSON, YAML, CSV, Markdown, Template}
+)
+
+// Result of a command. Header and Rows are printed as table, CSV and markdown.
+// Value is printed as JSON and YAML and is a data of template; Rows mapped by Header are used if Value is nil
+type Result struct {
+	Header []string
+	Rows   [][]string
+	Value  interface{}
+}
+
+// Current returns format selected by global flags
+func Current() Format {
+	if IsJSON {
+		return JSON
+	}
+	if TemplateText != "" && Format(Selected) == Table {
+		return Template
+	}
+	return Format(Selected)
+}
+
+// Validate checks values of global output flags
+func Validate() error {
+	format := Current()
+	for _, supported := range formats {
+		if format != supported {
+			continue
+		}
+		if format == Template && TemplateText == "" {
+			return fmt.Errorf("--template is required for template output")
+		}
+		return nil
+	}
+	names := make([]string, 0, len(formats))
+	for _, supported := range formats {
+		names = append(names, string(supported))
+	}
+	return fmt.Errorf("invalid output '%s'; please provide one of: %s", Selected, strings.Join(names, ", "))
+}
+
+// Print prints result into Stdout in selected format
+func Print(result *Result) error {
+	content, err := Render(Current(), result)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(Stdout, content)
+	return err
+}
+
+// Render returns result formatted with the format
+func Render(format Format, result *Result) (string, error) {
+	switch format {
+	case JSON:
+		content, err := json.MarshalIndent(value(result), "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(content) + "\n", nil
+	case YAML:
+		plain, err := plainValue(result)
+		if err != nil {
+			return "", err
+		}
+		content, err := yaml.Marshal(plain)
+		return string(content), err
+	case CSV:
+		return csvContent(result)
+	case Markdown:
+		return markdownContent(result), nil
+	case Template:
+		return templateContent(result)
+	}
+	return tableContent(result), nil
+}
+
+func value(result *Result) interface{} {
+	if result.Value != nil {
+		return result.Value
+	}
+	rows := make([]map[string]string, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		mapped := make(map[string]string, len(result.Header))
+		for i, column := range result.Header {
+			if i < len(row) {
+				mapped[column] = row[i]
+			}
+		}
+		rows = append(rows, mapped)
+	}
+	return rows
+}
+
+// plainValue converts value into maps and slices, so YAML and templates use the same field names as JSON
+func plainValue(result *Result) (interface{}, error) {
+	content, err := json.Marshal(value(result))
+	if err != nil {
+		return nil, err
+	}
+	var plain interface{}
+	err = json.Unmarshal(content, &plain)
+	return plain, err
+}
+
+func tableContent(result *Result) string {
+	var buffer bytes.Buffer
+	table := tablewriter.NewWriter(&buffer)
+	table.SetHeader(result.Header)
+	table.AppendBulk(result.Rows)
+	table.Render()
+	return buffer.String()
+}
+
+func csvContent(result *Result) (string, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(result.Header); err != nil {
+		return "", err
+	}
+	if err := writer.WriteAll(result.Rows); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func markdownContent(result *Result) string {
+	escape := strings.NewReplacer("|", `\|`, "\n", " ")
+	line := func(cells []string) string {
+		escaped := make([]string, 0, len(cells))
+		for _, cell := range cells {
+			escaped = append(escaped, escape.Replace(cell))
+		}
+		return "| " + strings.Join(escaped, " | ") + " |\n"
+	}
+	separator := make([]string, len(result.Header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	content := line(result.Header) + line(separator)
+	for _, row := range result.Rows {
+		content += line(row)
+	}
+	return content
+}
+
+func templateContent(result *Result) (string, error) {
+	tmpl, err := template.New("output").Parse(TemplateText)
+	if err != nil {
+		return "", err
+	}
+	plain, err := plainValue(result)
+	if err != nil {
+		return "", err
+	}
+	var buffer bytes.Buffer
+	if err = tmpl.Execute(&buffer, plain); err != nil {
+		return "", err
+	}
+	content := buffer.String()
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return content, nil
+}
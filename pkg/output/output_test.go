@@ -0,0 +1,72 @@
+package output
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type scoreRow struct {
+	File  string  `json:"file"`
+	Score float64 `json:"score"`
+}
+
+var result = &Result{
+	Header: []string{"FILE", "SCORE"},
+	Rows:   [][]string{{"en.json", "7.50"}, {"a|b.json", "9.00"}},
+	Value:  []scoreRow{{File: "en.json", Score: 7.5}, {File: "a|b.json", Score: 9}},
+}
+
+func TestRender(t *testing.T) {
+	content, err := Render(JSON, result)
+	assert.Nil(t, err)
+	assert.Equal(t, "[\n  {\n    \"file\": \"en.json\",\n    \"score\": 7.5\n  },\n  {\n    \"file\": \"a|b.json\",\n    \"score\": 9\n  }\n]\n", content)
+
+	content, err = Render(YAML, result)
+	assert.Nil(t, err)
+	assert.Equal(t, "- file: en.json\n  score: 7.5\n- file: a|b.json\n  score: 9\n", content)
+
+	content, err = Render(CSV, result)
+	assert.Nil(t, err)
+	assert.Equal(t, "FILE,SCORE\nen.json,7.50\na|b.json,9.00\n", content)
+
+	content, err = Render(Markdown, result)
+	assert.Nil(t, err)
+	assert.Equal(t, "| FILE | SCORE |\n| --- | --- |\n| en.json | 7.50 |\n| a\\|b.json | 9.00 |\n", content)
+
+	content, err = Render(Table, result)
+	assert.Nil(t, err)
+	assert.Contains(t, content, "| en.json  |  7.50 |")
+}
+
+func TestRenderRows(t *testing.T) {
+	content, err := Render(JSON, &Result{Header: []string{"KEY"}, Rows: [][]string{{"/a"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, "[\n  {\n    \"KEY\": \"/a\"\n  }\n]\n", content)
+}
+
+func TestPrintTemplate(t *testing.T) {
+	var buffer bytes.Buffer
+	stdout := Stdout
+	Stdout, TemplateText = &buffer, `{{range .}}{{.file}}={{.score}} {{end}}`
+	defer func() { Stdout, TemplateText = stdout, "" }()
+	assert.Nil(t, Validate())
+	assert.Equal(t, Template, Current())
+	assert.Nil(t, Print(result))
+	assert.Equal(t, "en.json=7.5 a|b.json=9 \n", buffer.String())
+
+	TemplateText = "{{.file"
+	assert.NotNil(t, Print(result))
+}
+
+func TestValidate(t *testing.T) {
+	defer func() { Selected, IsJSON = string(Table), false }()
+	assert.Nil(t, Validate())
+	Selected = "xml"
+	assert.EqualError(t, Validate(), "invalid output 'xml'; please provide one of: table, json, yaml, csv, markdown, template")
+	IsJSON = true
+	assert.Nil(t, Validate())
+	assert.Equal(t, JSON, Current())
+	Selected, IsJSON = string(Template), false
+	assert.EqualError(t, Validate(), "--template is required for template output")
+}
@@ -0,0 +1,185 @@
+package pseudo
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	rtlOverride    = "\u202e"
+	popDirectional = "\u202c"
+	padding        = "~"
+)
+
+var (
+	// protectedPattern matches parts of value that have to stay untouched: escape sequences, HTML entities,
+	// CDATA markers, markup tags, printf specifiers and `{{interpolations}}`
+	protectedPattern = regexp.MustCompile(`\\u[0-9a-fA-F]{4}|\\.|&(#[0-9]+|#x[0-9a-fA-F]+|[a-zA-Z]+);|<!\[CDATA\[|\]\]>|</?[a-zA-Z][^<>]*>|` +
+		`%%|%(\d+\$)?[-+#0]*(\d+|\*)?(\.(\d+|\*))?(hh|h|ll|l|L|q|j|z|t)?[diouxXeEfFgGaAcspn@]|\{\{[^{}]*\}\}`)
+	accents = map[rune]rune{
+		'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Đ', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ',
+		'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ',
+		'U': 'Û', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+		'a': 'å', 'b': 'ƀ', 'c': 'ç', 'd': 'đ', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î', 'j': 'ĵ',
+		'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ',
+		'u': 'û', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	}
+)
+
+// Options of pseudo-localization
+type Options struct {
+	// Accents replaces latin letters with accented ones
+	Accents bool
+	// Expansion is a ratio of text length added as padding, e.g. 0.3 makes text 30% longer
+	Expansion float64
+	// Brackets wraps value with `[` and `]` to reveal truncated and concatenated strings
+	Brackets bool
+	// RTL wraps text with right-to-left override characters to mirror it
+	RTL bool
+}
+
+// DefaultOptions are options of `en-xa` pseudo-language
+func DefaultOptions() Options {
+	return Options{
+		Accents:   true,
+		Expansion: 0.3,
+		Brackets:  true,
+	}
+}
+
+// Localize returns pseudo-localized value. Placeholders, markup and escape sequences are kept untouched,
+// so result is valid in the same file format as the value
+func Localize(value string, options Options) string {
+	if strings.TrimSpace(value) == "" {
+		return value
+	}
+	l := &localizer{text: value, options: options}
+	var result strings.Builder
+	l.message(&result, false, false)
+	if options.Expansion > 0 {
+		result.WriteString(strings.Repeat(padding, int(math.Ceil(float64(l.letters)*options.Expansion))))
+	}
+	if options.Brackets {
+		return "[" + result.String() + "]"
+	}
+	return result.String()
+}
+
+// localizer walks through ICU message: text of messages and plural/select sub-messages is localized,
+// arguments and selectors are copied as is
+type localizer struct {
+	text    string
+	pos     int
+	options Options
+	letters int
+}
+
+func (l *localizer) message(result *strings.Builder, nested, plural bool) {
+	start := l.pos
+	for l.pos < len(l.text) {
+		c := l.text[l.pos]
+		if c == '}' && nested {
+			l.writeText(result, l.text[start:l.pos])
+			return
+		}
+		if c == '{' && !strings.HasPrefix(l.text[l.pos:], "{{") {
+			l.writeText(result, l.text[start:l.pos])
+			l.argument(result)
+			start = l.pos
+			continue
+		}
+		if c == '#' && plural {
+			l.writeText(result, l.text[start:l.pos])
+			result.WriteByte(c)
+			l.pos++
+			start = l.pos
+			continue
+		}
+		l.pos++
+	}
+	l.writeText(result, l.text[start:])
+}
+
+// argument copies ICU argument starting at current `{`. Sub-messages of plural and select arguments are localized
+func (l *localizer) argument(result *strings.Builder) {
+	start := l.pos
+	header := strings.SplitN(l.text[start:], ",", 3)
+	if len(header) == 3 && !strings.ContainsAny(header[0][1:]+header[1], "{}") {
+		argType := strings.TrimSpace(header[1])
+		if argType == "plural" || argType == "select" || argType == "selectordinal" {
+			l.pos += len(header[0]) + len(header[1]) + 2
+			result.WriteString(l.text[start:l.pos])
+			l.subMessages(result, argType != "select")
+			return
+		}
+	}
+	// simple argument like `{name}` or `{amount, number}` is copied till matching brace
+	depth := 0
+	for l.pos < len(l.text) {
+		switch l.text[l.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+		l.pos++
+		if depth == 0 {
+			break
+		}
+	}
+	result.WriteString(l.text[start:l.pos])
+}
+
+func (l *localizer) subMessages(result *strings.Builder, plural bool) {
+	for l.pos < len(l.text) {
+		c := l.text[l.pos]
+		l.pos++
+		result.WriteByte(c)
+		switch c {
+		case '}':
+			return
+		case '{':
+			l.message(result, true, plural)
+			if l.pos < len(l.text) {
+				result.WriteByte(l.text[l.pos])
+				l.pos++
+			}
+		}
+	}
+}
+
+// writeText localizes text between placeholders
+func (l *localizer) writeText(result *strings.Builder, text string) {
+	if text == "" {
+		return
+	}
+	offset := 0
+	for _, match := range protectedPattern.FindAllStringIndex(text, -1) {
+		l.writeWords(result, text[offset:match[0]])
+		result.WriteString(text[match[0]:match[1]])
+		offset = match[1]
+	}
+	l.writeWords(result, text[offset:])
+}
+
+func (l *localizer) writeWords(result *strings.Builder, text string) {
+	if strings.TrimSpace(text) == "" {
+		result.WriteString(text)
+		return
+	}
+	l.letters += utf8.RuneCountInString(strings.TrimSpace(text))
+	if l.options.Accents {
+		text = strings.Map(func(r rune) rune {
+			if accented, ok := accents[r]; ok {
+				return accented
+			}
+			return r
+		}, text)
+	}
+	if l.options.RTL {
+		text = rtlOverride + text + popDirectional
+	}
+	result.WriteString(text)
+}
@@ -0,0 +1,27 @@
+package pseudo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLocalize(t *testing.T) {
+	tests := []struct {
+		value    string
+		options  Options
+		expected string
+	}{
+		{"Hello", DefaultOptions(), "[Ĥéļļö~~]"},
+		{"", DefaultOptions(), ""},
+		{"Hi {name}, <b>%1$s</b> {{count}}", Options{Accents: true}, "Ĥî {name}, <b>%1$s</b> {{count}}"},
+		{`Say \"hi\"\n &amp; go`, Options{Accents: true}, `Šåý \"ĥî\"\n &amp; ĝö`},
+		{"{count, plural, one {# item} other {# items}}", Options{Accents: true},
+			"{count, plural, one {# îţéɱ} other {# îţéɱš}}"},
+		{"{gender, select, male {He} other {They}}", Options{Accents: true}, "{gender, select, male {Ĥé} other {Ţĥéý}}"},
+		{"Total: {amount, number, ::currency/EUR}", Options{}, "Total: {amount, number, ::currency/EUR}"},
+		{"Left", Options{RTL: true}, "\u202eLeft\u202c"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, Localize(test.value, test.options), test.value)
+	}
+}
@@ -0,0 +1,255 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"regexp"
+	"strings"
+)
+
+var indentPattern = regexp.MustCompile(`(?m)^([ \t]+)\S`)
+
+// Add returns file content with new entries appended. JSON and YAML entries are nested by key separator,
+// unless JSON file already has flat keys containing it (YAML file is re-encoded, so comments aren't preserved)
+func (f *File) Add(entries []Entry) ([]byte, error) {
+	if len(entries) == 0 {
+		return f.content, nil
+	}
+	switch f.Format {
+	case JSON:
+		return f.addJSON(entries)
+	case YAML:
+		return addYAML(f.content, entries)
+	case AndroidXML:
+		return f.insertBefore("</resources>", entries, "    ", func(entry Entry, indent string) string {
+			value := strings.ReplaceAll(escapeXML(entry.Value), "'", `\'`)
+			return fmt.Sprintf("%s<string name=\"%s\">%s</string>\n", indent, escapeXML(entry.Key), value)
+		})
+	case RESX:
+		return f.insertBefore("</root>", entries, "  ", func(entry Entry, indent string) string {
+			return fmt.Sprintf("%[1]s<data name=\"%[2]s\" xml:space=\"preserve\">\n%[1]s%[1]s<value>%[3]s</value>\n%[1]s</data>\n",
+				indent, escapeXML(entry.Key), escapeXML(entry.Value))
+		})
+	case Properties:
+		return f.appendLines(entries, func(entry Entry) string {
+			return escapeProperty(entry.Key, true) + " = " + escapeProperty(entry.Value, false)
+		}), nil
+	case Strings:
+		return f.appendLines(entries, func(entry Entry) string {
+			return fmt.Sprintf(`"%s" = "%s";`, escapeStrings(entry.Key), escapeStrings(entry.Value))
+		}), nil
+	}
+	return nil, fmt.Errorf("adding keys to %s files is not supported", f.Format)
+}
+
+func (f *File) addJSON(entries []Entry) ([]byte, error) {
+	content := f.content
+	indent := detectIndent(content, "  ")
+	isFlat := hasFlatKeys(f.Entries)
+	for _, entry := range entries {
+		path := strings.Split(entry.Key, keySeparator)
+		if isFlat {
+			path = []string{entry.Key}
+		}
+		var err error
+		if content, err = f.insertJSON(content, path, entry.Value, indent); err != nil {
+			return nil, err
+		}
+	}
+	return content, nil
+}
+
+// hasFlatKeys checks if top-level keys of file contain key separator, like `{"menu.open": "Open"}`
+func hasFlatKeys(entries []Entry) bool {
+	for _, entry := range entries {
+		if len(entry.path) > 0 && strings.Contains(entry.path[0], keySeparator) {
+			return true
+		}
+	}
+	return false
+}
+
+// insertJSON adds value to the deepest existing object of key path, missing objects are created.
+// The rest of content is kept as is
+func (f *File) insertJSON(content []byte, path []string, value string, indent string) ([]byte, error) {
+	p := &jsonParser{content: content}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	values := make(map[string]bool, len(p.entries))
+	for _, entry := range p.entries {
+		values[entry.Key] = true
+	}
+	key := strings.Join(path, keySeparator)
+	if _, isObject := p.objects[key]; isObject || values[key] {
+		return nil, fmt.Errorf("key '%s' already exists", key)
+	}
+	depth := len(path) - 1
+	object, ok := p.objects[strings.Join(path[:depth], keySeparator)]
+	for !ok && depth > 0 {
+		if parent := strings.Join(path[:depth], keySeparator); values[parent] {
+			return nil, fmt.Errorf("key '%s' is not an object", parent)
+		}
+		depth--
+		object, ok = p.objects[strings.Join(path[:depth], keySeparator)]
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s has no top-level JSON object", f.Path)
+	}
+	// insert right after the last value to keep closing brace on its own line
+	insertAt := object.start + 1 + len(bytes.TrimRight(content[object.start+1:object.end], " \t\r\n"))
+	isEmpty := insertAt == object.start+1
+	var result bytes.Buffer
+	result.Write(content[:insertAt])
+	if !isEmpty {
+		result.WriteString(",")
+	}
+	result.WriteString("\n" + strings.Repeat(indent, depth+1))
+	writeJSONValue(&result, path[depth:], value, indent, depth+1)
+	if isEmpty {
+		result.WriteString("\n" + strings.Repeat(indent, depth))
+	}
+	result.Write(content[insertAt:])
+	return result.Bytes(), nil
+}
+
+// writeJSONValue writes `"key": value`, wrapping value into objects for every next key of path
+func writeJSONValue(result *bytes.Buffer, path []string, value string, indent string, depth int) {
+	result.WriteString(jsonString(path[0]) + ": ")
+	if len(path) == 1 {
+		result.WriteString(jsonString(value))
+		return
+	}
+	result.WriteString("{\n" + strings.Repeat(indent, depth+1))
+	writeJSONValue(result, path[1:], value, indent, depth+1)
+	result.WriteString("\n" + strings.Repeat(indent, depth) + "}")
+}
+
+func addYAML(content []byte, entries []Entry) ([]byte, error) {
+	var document yaml.MapSlice
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, yamlSyntaxError(err)
+	}
+	// new keys of Rails-like files are added under root locale key
+	root := yaml.MapSlice(nil)
+	_, hasLocaleRoot := localeRoot(document)
+	if hasLocaleRoot {
+		root = document[0].Value.(yaml.MapSlice)
+	}
+	target := &document
+	if hasLocaleRoot {
+		target = &root
+	}
+	for _, entry := range entries {
+		if err := insertYAML(target, strings.Split(entry.Key, keySeparator), entry.Value); err != nil {
+			return nil, err
+		}
+	}
+	if hasLocaleRoot {
+		document[0].Value = root
+	}
+	return yaml.Marshal(document)
+}
+
+func insertYAML(node *yaml.MapSlice, path []string, value string) error {
+	for i := range *node {
+		item := &(*node)[i]
+		if fmt.Sprint(item.Key) != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			return fmt.Errorf("key '%s' already exists", path[0])
+		}
+		child, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			return fmt.Errorf("key '%s' is not a map", path[0])
+		}
+		if err := insertYAML(&child, path[1:], value); err != nil {
+			return err
+		}
+		item.Value = child
+		return nil
+	}
+	if len(path) == 1 {
+		*node = append(*node, yaml.MapItem{Key: path[0], Value: value})
+		return nil
+	}
+	child := yaml.MapSlice{}
+	if err := insertYAML(&child, path[1:], value); err != nil {
+		return err
+	}
+	*node = append(*node, yaml.MapItem{Key: path[0], Value: child})
+	return nil
+}
+
+func (f *File) insertBefore(closingTag string, entries []Entry, defaultIndent string, format func(entry Entry, indent string) string) ([]byte, error) {
+	end := bytes.LastIndex(f.content, []byte(closingTag))
+	if end < 0 {
+		return nil, fmt.Errorf("%s has no closing %s tag", f.Path, closingTag)
+	}
+	// insert at the beginning of closing tag's line
+	lineStart := bytes.LastIndexByte(f.content[:end], '\n') + 1
+	if len(bytes.TrimSpace(f.content[lineStart:end])) > 0 {
+		lineStart = end
+	}
+	indent := detectIndent(f.content, defaultIndent)
+	var result bytes.Buffer
+	result.Write(f.content[:lineStart])
+	if lineStart == end && lineStart > 0 && f.content[lineStart-1] != '\n' {
+		result.WriteString("\n")
+	}
+	for _, entry := range entries {
+		result.WriteString(format(entry, indent))
+	}
+	result.Write(f.content[lineStart:])
+	return result.Bytes(), nil
+}
+
+func (f *File) appendLines(entries []Entry, format func(entry Entry) string) []byte {
+	var result bytes.Buffer
+	result.Write(f.content)
+	if len(f.content) > 0 && !bytes.HasSuffix(f.content, []byte("\n")) {
+		result.WriteString("\n")
+	}
+	for _, entry := range entries {
+		result.WriteString(format(entry) + "\n")
+	}
+	return result.Bytes()
+}
+
+func detectIndent(content []byte, defaultIndent string) string {
+	if match := indentPattern.FindSubmatch(content); match != nil {
+		return string(match[1])
+	}
+	return defaultIndent
+}
+
+func jsonString(value string) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+func escapeXML(value string) string {
+	var buffer bytes.Buffer
+	_ = xml.EscapeText(&buffer, []byte(value))
+	return buffer.String()
+}
+
+func escapeProperty(value string, isKey bool) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\t", `\t`)
+	value = replacer.Replace(value)
+	if isKey {
+		value = strings.NewReplacer(" ", `\ `, "=", `\=`, ":", `\:`).Replace(value)
+	}
+	return value
+}
+
+func escapeStrings(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`).Replace(value)
+}
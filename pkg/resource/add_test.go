@@ -0,0 +1,55 @@
+package resource
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	entries := []Entry{{Key: "new.key", Value: `Say "hi" & go`}, {Key: "other", Value: "x"}}
+	tests := []struct {
+		path     string
+		content  string
+		expected string
+	}{
+		{"en.json", "{\n    \"a\": \"A\"\n}\n", "{\n    \"a\": \"A\",\n    \"new\": {\n        \"key\": \"Say \\\"hi\\\" & go\"\n    },\n    \"other\": \"x\"\n}\n"},
+		{"en.json", "{}", "{\n  \"new\": {\n    \"key\": \"Say \\\"hi\\\" & go\"\n  },\n  \"other\": \"x\"\n}"},
+		{"en.json", "{\n  \"new\": {\n    \"old\": \"A\"\n  },\n  \"z\": {}\n}", "{\n  \"new\": {\n    \"old\": \"A\",\n    \"key\": \"Say \\\"hi\\\" & go\"\n  },\n  \"z\": {},\n  \"other\": \"x\"\n}"},
+		{"en.yml", "en:\n  new:\n    old: A\n", "en:\n  new:\n    old: A\n    key: Say \"hi\" & go\n  other: x\n"},
+		{"strings.xml", "<resources>\n  <string name=\"a\">A</string>\n</resources>\n",
+			"<resources>\n  <string name=\"a\">A</string>\n  <string name=\"new.key\">Say &#34;hi&#34; &amp; go</string>\n  <string name=\"other\">x</string>\n</resources>\n"},
+		{"en.properties", "a = A", "a = A\nnew.key = Say \"hi\" & go\nother = x\n"},
+		{"en.strings", "\"a\" = \"A\";\n", "\"a\" = \"A\";\n\"new.key\" = \"Say \\\"hi\\\" & go\";\n\"other\" = \"x\";\n"},
+	}
+	for _, test := range tests {
+		file, err := Parse(test.path, []byte(test.content))
+		assert.Nil(t, err)
+		result, err := file.Add(entries)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, string(result), test.path)
+		added, err := Parse(test.path, result)
+		assert.Nil(t, err, test.path)
+		assert.Equal(t, `Say "hi" & go`, added.Values()["new.key"], test.path)
+	}
+	for path, content := range map[string]string{
+		"en.yml":  "new:\n  key: A\nz: Z\n",
+		"en.json": `{"new": {"key": "A"}}`,
+	} {
+		file, err := Parse(path, []byte(content))
+		assert.Nil(t, err)
+		_, err = file.Add(entries)
+		assert.NotNil(t, err, path)
+	}
+	file, err := Parse("en.json", []byte("{\n  \"menu.open\": \"Open\"\n}"))
+	assert.Nil(t, err)
+	result, err := file.Add(entries)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n  \"menu.open\": \"Open\",\n  \"new.key\": \"Say \\\"hi\\\" & go\",\n  \"other\": \"x\"\n}", string(result))
+	_, err = file.Add([]Entry{{Key: "menu.open", Value: "x"}})
+	assert.EqualError(t, err, "key 'menu.open' already exists")
+
+	file, err = Parse("en.json", []byte(`{"new": "A"}`))
+	assert.Nil(t, err)
+	_, err = file.Add(entries)
+	assert.EqualError(t, err, "key 'new' is not an object")
+}
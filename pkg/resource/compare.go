@@ -0,0 +1,191 @@
+package resource
+
+import (
+	"strings"
+)
+
+// DifferenceKind is a kind of difference between source and target files
+type DifferenceKind string
+
+// kinds of differences between source and target files
+const (
+	MissingKey         DifferenceKind = "missing key"
+	ExtraKey           DifferenceKind = "extra key"
+	MissingPlaceholder DifferenceKind = "missing placeholder"
+	ExtraPlaceholder   DifferenceKind = "extra placeholder"
+)
+
+// Difference is a single inconsistency of target file comparing with source file
+type Difference struct {
+	Key     string
+	Line    int
+	Kind    DifferenceKind
+	Details string
+}
+
+// Compare checks target file against source file key by key. Placeholders are checked only for translated values.
+// Android plurals are compared as a whole, as target language might have other plural categories than source
+func Compare(source, target *File) []Difference {
+	differences := make([]Difference, 0)
+	targetEntries := make(map[string]Entry, len(target.Entries))
+	targetPlurals := pluralValues(target.Entries)
+	for _, entry := range target.Entries {
+		targetEntries[entry.Key] = entry
+	}
+	sourceKeys := make(map[string]bool, len(source.Entries))
+	sourcePlurals := pluralValues(source.Entries)
+	for _, sourceEntry := range source.Entries {
+		if sourceEntry.pluralOf != "" {
+			if sourceKeys[sourceEntry.pluralOf] {
+				continue
+			}
+			sourceKeys[sourceEntry.pluralOf] = true
+			plural, ok := targetPlurals[sourceEntry.pluralOf]
+			if !ok {
+				differences = append(differences, Difference{Key: sourceEntry.pluralOf, Line: sourceEntry.Line, Kind: MissingKey})
+				continue
+			}
+			missing, extra := comparePlaceholderSets(sourcePlurals[sourceEntry.pluralOf].values, plural.values)
+			differences = appendPlaceholderDifferences(differences, sourceEntry.pluralOf, plural.line, missing, extra)
+			continue
+		}
+		sourceKeys[sourceEntry.Key] = true
+		targetEntry, ok := targetEntries[sourceEntry.Key]
+		if !ok {
+			differences = append(differences, Difference{Key: sourceEntry.Key, Line: sourceEntry.Line, Kind: MissingKey})
+			continue
+		}
+		if strings.TrimSpace(targetEntry.Value) == "" {
+			continue
+		}
+		missing, extra := ComparePlaceholders(sourceEntry.Value, targetEntry.Value)
+		differences = appendPlaceholderDifferences(differences, sourceEntry.Key, targetEntry.Line, missing, extra)
+	}
+	reportedPlurals := make(map[string]bool)
+	for _, targetEntry := range target.Entries {
+		key := targetEntry.Key
+		if targetEntry.pluralOf != "" {
+			key = targetEntry.pluralOf
+			if reportedPlurals[key] {
+				continue
+			}
+			reportedPlurals[key] = true
+		}
+		if !sourceKeys[key] {
+			differences = append(differences, Difference{Key: key, Line: targetEntry.Line, Kind: ExtraKey})
+		}
+	}
+	return differences
+}
+
+// plural is translated values of all categories of Android plurals element
+type plural struct {
+	line   int
+	values []string
+}
+
+func pluralValues(entries []Entry) map[string]*plural {
+	plurals := make(map[string]*plural)
+	for _, entry := range entries {
+		if entry.pluralOf == "" {
+			continue
+		}
+		item, ok := plurals[entry.pluralOf]
+		if !ok {
+			item = &plural{line: entry.Line}
+			plurals[entry.pluralOf] = item
+		}
+		if strings.TrimSpace(entry.Value) != "" {
+			item.values = append(item.values, entry.Value)
+		}
+	}
+	return plurals
+}
+
+// comparePlaceholderSets compares union of placeholders of all source values with union of target ones.
+// Untranslated target isn't compared
+func comparePlaceholderSets(sourceValues, targetValues []string) (missing, extra []string) {
+	if len(targetValues) == 0 {
+		return nil, nil
+	}
+	sourceSet := placeholderSet(sourceValues)
+	targetSet := placeholderSet(targetValues)
+	return diffCounts(sourceSet, targetSet), diffCounts(targetSet, sourceSet)
+}
+
+func placeholderSet(values []string) map[string]int {
+	set := make(map[string]int)
+	for _, value := range values {
+		for _, placeholder := range Placeholders(value) {
+			set[placeholder] = 1
+		}
+	}
+	return set
+}
+
+func appendPlaceholderDifferences(differences []Difference, key string, line int, missing, extra []string) []Difference {
+	if len(missing) > 0 {
+		differences = append(differences, Difference{
+			Key:     key,
+			Line:    line,
+			Kind:    MissingPlaceholder,
+			Details: strings.Join(missing, " "),
+		})
+	}
+	if len(extra) > 0 {
+		differences = append(differences, Difference{
+			Key:     key,
+			Line:    line,
+			Kind:    ExtraPlaceholder,
+			Details: strings.Join(extra, " "),
+		})
+	}
+	return differences
+}
+
+// Coverage is a translation state of target file comparing with source file
+type Coverage struct {
+	Total        int `json:"total"`
+	Translated   int `json:"translated"`
+	Untranslated int `json:"untranslated"`
+	Identical    int `json:"identical"`
+}
+
+// CompareCoverage counts source keys, which are translated, untranslated (absent or empty in target) and
+// identical to source. Target may be nil if translation file doesn't exist
+func CompareCoverage(source, target *File) Coverage {
+	targetValues := make(map[string]string)
+	if target != nil {
+		targetValues = target.Values()
+	}
+	coverage := Coverage{}
+	for key, sourceValue := range source.Values() {
+		coverage.Total++
+		targetValue, ok := targetValues[key]
+		switch {
+		case !ok || strings.TrimSpace(targetValue) == "":
+			coverage.Untranslated++
+		case targetValue == sourceValue:
+			coverage.Identical++
+		default:
+			coverage.Translated++
+		}
+	}
+	return coverage
+}
+
+// Add sums coverage of several files
+func (c *Coverage) Add(other Coverage) {
+	c.Total += other.Total
+	c.Translated += other.Translated
+	c.Untranslated += other.Untranslated
+	c.Identical += other.Identical
+}
+
+// TranslatedPercent is a percent of translated keys
+func (c Coverage) TranslatedPercent() float64 {
+	if c.Total == 0 {
+		return 0
+	}
+	return float64(c.Translated) * 100 / float64(c.Total)
+}
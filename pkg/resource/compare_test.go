@@ -0,0 +1,71 @@
+package resource
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	source, err := Parse("en.json", []byte(`{"title": "Hello <b>{name}</b>", "count": "%d items", "gone": "Gone", "empty": "Empty"}`))
+	assert.Nil(t, err)
+	target, err := Parse("fr.json", []byte(`{"title": "Bonjour {name}", "count": "%d éléments", "empty": "", "new": "Nouveau"}`))
+	assert.Nil(t, err)
+	differences := Compare(source, target)
+	assert.Equal(t, []Difference{
+		{Key: "title", Line: 1, Kind: MissingPlaceholder, Details: "</b> <b>"},
+		{Key: "gone", Line: 1, Kind: MissingKey},
+		{Key: "new", Line: 1, Kind: ExtraKey},
+	}, differences)
+}
+
+func TestCompareAndroidPlurals(t *testing.T) {
+	source, err := Parse("values/strings.xml", []byte(`<resources>
+    <plurals name="files">
+        <item quantity="one">%d file</item>
+        <item quantity="other">%d files</item>
+    </plurals>
+    <plurals name="songs">
+        <item quantity="one">%d song by %s</item>
+        <item quantity="other">%d songs by %s</item>
+    </plurals>
+    <plurals name="gone">
+        <item quantity="other">Gone</item>
+    </plurals>
+</resources>`))
+	assert.Nil(t, err)
+	target, err := Parse("values-ru/strings.xml", []byte(`<resources>
+    <plurals name="files">
+        <item quantity="one">%d файл</item>
+        <item quantity="few">%d файла</item>
+        <item quantity="many">%d файлов</item>
+        <item quantity="other">%d файла</item>
+    </plurals>
+    <plurals name="songs">
+        <item quantity="one">%d песня</item>
+        <item quantity="few">%d песни</item>
+        <item quantity="many">%d песен</item>
+        <item quantity="other">%d песни</item>
+    </plurals>
+    <plurals name="new">
+        <item quantity="other">Новый</item>
+    </plurals>
+</resources>`))
+	assert.Nil(t, err)
+	assert.Equal(t, []Difference{
+		{Key: "songs", Line: 9, Kind: MissingPlaceholder, Details: "%s"},
+		{Key: "gone", Line: 11, Kind: MissingKey},
+		{Key: "new", Line: 15, Kind: ExtraKey},
+	}, Compare(source, target))
+}
+
+func TestCompareCoverage(t *testing.T) {
+	source, err := Parse("en.json", []byte(`{"a": "A", "b": "B", "c": "C", "d": "D", "e": "E"}`))
+	assert.Nil(t, err)
+	target, err := Parse("fr.json", []byte(`{"a": "Ah", "b": "B", "c": "", "x": "X"}`))
+	assert.Nil(t, err)
+	coverage := CompareCoverage(source, target)
+	assert.Equal(t, Coverage{Total: 5, Translated: 1, Untranslated: 3, Identical: 1}, coverage)
+	assert.Equal(t, 20.0, coverage.TranslatedPercent())
+	coverage.Add(CompareCoverage(source, nil))
+	assert.Equal(t, Coverage{Total: 10, Translated: 1, Untranslated: 8, Identical: 1}, coverage)
+}
@@ -0,0 +1,236 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const keySeparator = "."
+
+// jsonParser is a minimal JSON parser which keeps keys order, line numbers and raw value positions.
+// Standard `encoding/json` loses all of them
+type jsonParser struct {
+	content []byte
+	pos     int
+	line    int
+	entries []Entry
+	// objects are positions of objects' braces by their keys, top-level object has empty key
+	objects map[string]jsonObject
+}
+
+type jsonObject struct {
+	start int
+	end   int
+}
+
+func parseJSON(content []byte) ([]Entry, error) {
+	p := &jsonParser{content: content}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.entries, nil
+}
+
+func (p *jsonParser) parse() error {
+	p.line = 1
+	p.objects = make(map[string]jsonObject)
+	if err := p.parseValue(nil); err != nil {
+		return err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.content) {
+		return p.errorf("unexpected %q after top-level value", p.content[p.pos])
+	}
+	return nil
+}
+
+func (p *jsonParser) parseValue(path []string) error {
+	p.skipSpaces()
+	if p.pos >= len(p.content) {
+		return p.errorf("unexpected end of file")
+	}
+	start := p.pos
+	c := p.content[p.pos]
+	switch {
+	case c == '{':
+		return p.parseObject(path)
+	case c == '[':
+		return p.parseArray(path)
+	case c == '"':
+		value, err := p.parseString()
+		if err != nil {
+			return err
+		}
+		p.addEntry(path, value, start)
+	case c == 'n':
+		if err := p.parseLiteral("null"); err != nil {
+			return err
+		}
+		p.addEntry(path, "", start)
+	case c == 't':
+		return p.parseLiteral("true")
+	case c == 'f':
+		return p.parseLiteral("false")
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	default:
+		return p.errorf("unexpected character %q", c)
+	}
+	return nil
+}
+
+func (p *jsonParser) addEntry(path []string, value string, start int) {
+	p.entries = append(p.entries, Entry{
+		Key:   strings.Join(path, keySeparator),
+		Value: value,
+		Line:  p.line,
+		path:  path,
+		start: start,
+		end:   p.pos,
+	})
+}
+
+func (p *jsonParser) parseObject(path []string) error {
+	start := p.pos
+	closeObject := func() {
+		p.objects[strings.Join(path, keySeparator)] = jsonObject{start: start, end: p.pos}
+		p.pos++
+	}
+	// skip '{'
+	p.pos++
+	p.skipSpaces()
+	if p.peek() == '}' {
+		closeObject()
+		return nil
+	}
+	for {
+		p.skipSpaces()
+		if p.peek() != '"' {
+			return p.errorf("object key should be a string")
+		}
+		key, err := p.parseString()
+		if err != nil {
+			return err
+		}
+		p.skipSpaces()
+		if p.peek() != ':' {
+			return p.errorf("missing ':' after key %q", key)
+		}
+		p.pos++
+		if err = p.parseValue(appendPath(path, key)); err != nil {
+			return err
+		}
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			closeObject()
+			return nil
+		default:
+			return p.errorf("missing ',' or '}' after value of key %q", key)
+		}
+	}
+}
+
+func (p *jsonParser) parseArray(path []string) error {
+	// skip '['
+	p.pos++
+	p.skipSpaces()
+	if p.peek() == ']' {
+		p.pos++
+		return nil
+	}
+	for i := 0; ; i++ {
+		if err := p.parseValue(appendPath(path, strconv.Itoa(i))); err != nil {
+			return err
+		}
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return nil
+		default:
+			return p.errorf("missing ',' or ']' in array")
+		}
+	}
+}
+
+func (p *jsonParser) parseString() (string, error) {
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.content) {
+		switch p.content[p.pos] {
+		case '\\':
+			p.pos += 2
+			continue
+		case '\n':
+			return "", p.errorf("unterminated string")
+		case '"':
+			p.pos++
+			var value string
+			if err := json.Unmarshal(p.content[start:p.pos], &value); err != nil {
+				return "", p.errorf("invalid string %s", p.content[start:p.pos])
+			}
+			return value, nil
+		}
+		p.pos++
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *jsonParser) parseLiteral(literal string) error {
+	if !strings.HasPrefix(string(p.content[p.pos:]), literal) {
+		return p.errorf("invalid literal, expected %q", literal)
+	}
+	p.pos += len(literal)
+	return nil
+}
+
+func (p *jsonParser) parseNumber() error {
+	start := p.pos
+	for p.pos < len(p.content) && strings.IndexByte("+-.eE0123456789", p.content[p.pos]) >= 0 {
+		p.pos++
+	}
+	if _, err := strconv.ParseFloat(string(p.content[start:p.pos]), 64); err != nil {
+		return p.errorf("invalid number %s", p.content[start:p.pos])
+	}
+	return nil
+}
+
+func (p *jsonParser) skipSpaces() {
+	for p.pos < len(p.content) {
+		switch p.content[p.pos] {
+		case '\n':
+			p.line++
+		case ' ', '\t', '\r':
+		default:
+			return
+		}
+		p.pos++
+	}
+}
+
+func (p *jsonParser) peek() byte {
+	if p.pos >= len(p.content) {
+		return 0
+	}
+	return p.content[p.pos]
+}
+
+func (p *jsonParser) errorf(format string, v ...interface{}) error {
+	return &SyntaxError{
+		Line: p.line,
+		Msg:  fmt.Sprintf(format, v...),
+	}
+}
+
+func appendPath(path []string, key string) []string {
+	result := make([]string, len(path), len(path)+1)
+	copy(result, path)
+	return append(result, key)
+}
@@ -0,0 +1,256 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	printfPattern      = regexp.MustCompile(`%(\d+\$)?[-+#0]*(\d+|\*)?(\.(\d+|\*))?(hh|h|ll|l|L|q|j|z|t)?([diouxXeEfFgGaAcspn@])`)
+	doubleBracePattern = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+	tagPattern         = regexp.MustCompile(`</?([a-zA-Z][a-zA-Z0-9-]*)[^<>]*?(/?)>`)
+)
+
+// Placeholders returns sorted list of ICU arguments, `{{interpolations}}`, printf specifiers and markup tags of value.
+// The same placeholder is returned as many times as it appears in value
+func Placeholders(value string) []string {
+	result := make([]string, 0)
+	value = doubleBracePattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := doubleBracePattern.FindStringSubmatch(match)[1]
+		result = append(result, "{{"+name+"}}")
+		return strings.Repeat(" ", len(match))
+	})
+	result = append(result, printfSpecifiers(value)...)
+	for _, match := range tagPattern.FindAllStringSubmatch(value, -1) {
+		result = append(result, normalizeTag(match[0], match[1], match[2]))
+	}
+	// ICU arguments of invalid messages are ignored. Problem is reported by PlaceholderProblems
+	parser := &icuParser{text: value}
+	if err := parser.message(false); err == nil {
+		result = append(result, parser.args...)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// PlaceholderProblems returns syntax problems of placeholders in value: unbalanced ICU braces,
+// mixed positional and sequential printf specifiers. Suspicious, but valid in non-ICU formats, placeholders
+// like empty `{}` are returned as warnings
+func PlaceholderProblems(value string) (problems, warnings []string) {
+	problems = make([]string, 0)
+	value = doubleBracePattern.ReplaceAllStringFunc(value, func(match string) string {
+		return strings.Repeat(" ", len(match))
+	})
+	parser := &icuParser{text: value}
+	if err := parser.message(false); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid ICU message: %v", err))
+	}
+	warnings = parser.warnings
+	positional, sequential := 0, 0
+	for _, specifier := range printfSpecifiers(value) {
+		if strings.Contains(specifier, "$") {
+			positional++
+		} else {
+			sequential++
+		}
+	}
+	if positional > 0 && sequential > 0 {
+		problems = append(problems, "positional (%1$s) and sequential (%s) printf placeholders are mixed")
+	}
+	return problems, warnings
+}
+
+// ComparePlaceholders returns placeholders that are present in source but absent in target (missing)
+// and present in target but absent in source (extra). ICU arguments are compared by name only, as plural and select
+// branches repeat them and languages have different number of plural categories
+func ComparePlaceholders(source, target string) (missing, extra []string) {
+	sourceCounts := countItems(Placeholders(source))
+	targetCounts := countItems(Placeholders(target))
+	missing = diffCounts(sourceCounts, targetCounts)
+	extra = diffCounts(targetCounts, sourceCounts)
+	return missing, extra
+}
+
+func countItems(items []string) map[string]int {
+	counts := make(map[string]int, len(items))
+	for _, item := range items {
+		if isICUArgument(item) {
+			counts[item] = 1
+			continue
+		}
+		counts[item]++
+	}
+	return counts
+}
+
+func isICUArgument(item string) bool {
+	return strings.HasPrefix(item, "{") && !strings.HasPrefix(item, "{{")
+}
+
+func diffCounts(from, to map[string]int) []string {
+	result := make([]string, 0)
+	for item, count := range from {
+		for i := to[item]; i < count; i++ {
+			result = append(result, item)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func printfSpecifiers(value string) []string {
+	value = strings.ReplaceAll(value, "%%", "  ")
+	result := make([]string, 0)
+	for _, match := range printfPattern.FindAllStringSubmatch(value, -1) {
+		result = append(result, "%"+match[1]+match[5]+match[6])
+	}
+	return result
+}
+
+func normalizeTag(tag, name, selfClosing string) string {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasPrefix(tag, "</"):
+		return "</" + name + ">"
+	case selfClosing != "":
+		return "<" + name + "/>"
+	}
+	return "<" + name + ">"
+}
+
+// icuParser is a minimal parser of ICU MessageFormat, which collects argument names
+type icuParser struct {
+	text     string
+	pos      int
+	args     []string
+	warnings []string
+}
+
+func (p *icuParser) message(nested bool) error {
+	for p.pos < len(p.text) {
+		switch p.text[p.pos] {
+		case '\'':
+			// apostrophe quotes following syntax characters: '{' is literal brace
+			if p.pos+1 < len(p.text) && (p.text[p.pos+1] == '{' || p.text[p.pos+1] == '}') {
+				end := strings.IndexByte(p.text[p.pos+1:], '\'')
+				if end < 0 {
+					p.pos = len(p.text)
+				} else {
+					p.pos += end + 2
+				}
+				continue
+			}
+		case '{':
+			p.pos++
+			if err := p.argument(); err != nil {
+				return err
+			}
+			continue
+		case '}':
+			if nested {
+				p.pos++
+				return nil
+			}
+			return fmt.Errorf("unexpected '}' at position %d", p.pos+1)
+		}
+		p.pos++
+	}
+	if nested {
+		return errors.New("missing closing '}' of sub-message")
+	}
+	return nil
+}
+
+func (p *icuParser) argument() error {
+	name, delimiter := p.readUntil(",}{")
+	switch {
+	case delimiter == 0:
+		return errors.New("missing closing '}' of argument")
+	case delimiter == '{':
+		return fmt.Errorf("unexpected '{' at position %d", p.pos+1)
+	case name == "" && delimiter == '}':
+		// `{}` is kept as literal text, e.g. of C# or Python format strings
+		p.warnings = append(p.warnings, fmt.Sprintf("empty argument '{}' at position %d", p.pos))
+		p.pos++
+		return nil
+	case name == "":
+		return fmt.Errorf("empty argument at position %d", p.pos)
+	}
+	p.args = append(p.args, "{"+name+"}")
+	p.pos++
+	if delimiter == '}' {
+		return nil
+	}
+	argType, delimiter := p.readUntil(",}")
+	if delimiter == 0 {
+		return fmt.Errorf("missing closing '}' of argument {%s}", name)
+	}
+	p.pos++
+	if delimiter == '}' {
+		return nil
+	}
+	switch argType {
+	case "plural", "select", "selectordinal":
+		return p.subMessages(name)
+	}
+	// skip argument style like `{amount, number, ::currency/EUR}`
+	for depth := 1; p.pos < len(p.text); p.pos++ {
+		switch p.text[p.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("missing closing '}' of argument {%s}", name)
+}
+
+func (p *icuParser) subMessages(name string) error {
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.text) {
+			return fmt.Errorf("missing closing '}' of argument {%s}", name)
+		}
+		if p.text[p.pos] == '}' {
+			p.pos++
+			return nil
+		}
+		selector, _ := p.readUntil(" \t\n{}")
+		p.skipSpaces()
+		if strings.HasPrefix(selector, "offset:") {
+			continue
+		}
+		if p.pos >= len(p.text) || p.text[p.pos] != '{' {
+			return fmt.Errorf("missing sub-message of selector '%s' in argument {%s}", selector, name)
+		}
+		p.pos++
+		if err := p.message(true); err != nil {
+			return err
+		}
+	}
+}
+
+// readUntil reads text till one of delimiters and returns trimmed text with found delimiter (0 if not found)
+func (p *icuParser) readUntil(delimiters string) (string, byte) {
+	start := p.pos
+	for p.pos < len(p.text) {
+		if strings.IndexByte(delimiters, p.text[p.pos]) >= 0 {
+			return strings.TrimSpace(p.text[start:p.pos]), p.text[p.pos]
+		}
+		p.pos++
+	}
+	return strings.TrimSpace(p.text[start:]), 0
+}
+
+func (p *icuParser) skipSpaces() {
+	for p.pos < len(p.text) && strings.IndexByte(" \t\r\n", p.text[p.pos]) >= 0 {
+		p.pos++
+	}
+}
@@ -0,0 +1,50 @@
+package resource
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPlaceholders(t *testing.T) {
+	assert.Equal(t, []string{"%1$s", "%d", "</b>", "<b>", "{name}"}, Placeholders("Hi <b>{name}</b>, %1$s has %d items"))
+	assert.Equal(t, []string{"{{count}}"}, Placeholders("{{count}} items"))
+	assert.Equal(t, []string{"{count}", "{name}"},
+		Placeholders("{count, plural, one {# item for {name}} other {# items}}"))
+	assert.Equal(t, []string{}, Placeholders("100%% sure, 50% off"))
+}
+
+func TestPlaceholderProblems(t *testing.T) {
+	assertProblems := func(value string, count int) {
+		problems, _ := PlaceholderProblems(value)
+		assert.Len(t, problems, count, value)
+	}
+	assertProblems("{count, plural, one {# item} other {# items}}", 0)
+	assertProblems("It'{'s literal", 0)
+	assertProblems("Hello {name", 1)
+	assertProblems("Hello name}", 1)
+	assertProblems("{count, plural, one}", 1)
+	assertProblems("%1$s and %s", 1)
+
+	problems, warnings := PlaceholderProblems("Hello {} and {name}")
+	assert.Empty(t, problems)
+	assert.Equal(t, []string{"empty argument '{}' at position 7"}, warnings)
+	assert.Equal(t, []string{"{name}"}, Placeholders("Hello {} and {name}"))
+}
+
+func TestComparePlaceholders(t *testing.T) {
+	missing, extra := ComparePlaceholders("Hello <b>{name}</b>, %d", "Bonjour {nom}, %d")
+	assert.Equal(t, []string{"</b>", "<b>", "{name}"}, missing)
+	assert.Equal(t, []string{"{nom}"}, extra)
+}
+
+func TestComparePlaceholdersPluralCategories(t *testing.T) {
+	missing, extra := ComparePlaceholders("{count, plural, one {{name} has # file} other {{name} has # files}}",
+		"{count, plural, one {{name} ma # plik} few {{name} ma # pliki} many {{name} ma # plików} other {{name} ma # pliku}}")
+	assert.Empty(t, missing)
+	assert.Empty(t, extra)
+
+	missing, extra = ComparePlaceholders("{count, plural, one {{name}: # file} other {{name}: # files}}",
+		"{count, plural, one {# plik} few {# pliki} many {# plików} other {{user}: # pliku}}")
+	assert.Equal(t, []string{"{name}"}, missing)
+	assert.Equal(t, []string{"{user}"}, extra)
+}
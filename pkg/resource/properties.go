@@ -0,0 +1,148 @@
+package resource
+
+import (
+	"regexp"
+	"strings"
+)
+
+var stringsEntry = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"\s*=\s*"((?:[^"\\]|\\.)*)"\s*;`)
+
+// parseProperties reads Java .properties file. Multi-line values (trailing backslash) are joined
+func parseProperties(content []byte) ([]Entry, error) {
+	entries := make([]Entry, 0)
+	lines := strings.SplitAfter(string(content), "\n")
+	offset := 0
+	for i := 0; i < len(lines); i++ {
+		lineStart := offset
+		offset += len(lines[i])
+		line := strings.TrimRight(lines[i], "\r\n")
+		trimmed := strings.TrimLeft(line, " \t\f")
+		if trimmed == "" || trimmed[0] == '#' || trimmed[0] == '!' {
+			continue
+		}
+		key, valueIndex := splitProperty(trimmed)
+		value := trimmed[valueIndex:]
+		valueStart := lineStart + len(line) - len(trimmed) + valueIndex
+		entry := Entry{
+			Key:   key,
+			Line:  i + 1,
+			start: valueStart,
+			end:   valueStart + len(value),
+		}
+		for strings.HasSuffix(value, `\`) && i+1 < len(lines) {
+			i++
+			offset += len(lines[i])
+			value = strings.TrimSuffix(value, `\`) + strings.TrimLeft(strings.TrimRight(lines[i], "\r\n"), " \t\f")
+		}
+		entry.Value = value
+		if entry.Line != i+1 {
+			// multi-line values are not replaced in place
+			entry.end = 0
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// splitProperty returns key and index where value starts
+func splitProperty(line string) (string, int) {
+	escaped := false
+	for i, c := range line {
+		if escaped {
+			escaped = false
+			continue
+		}
+		switch c {
+		case '\\':
+			escaped = true
+		case '=', ':', ' ', '\t':
+			key := line[:i]
+			rest := i
+			for rest < len(line) && (line[rest] == ' ' || line[rest] == '\t') {
+				rest++
+			}
+			if rest < len(line) && (line[rest] == '=' || line[rest] == ':') {
+				rest++
+			}
+			for rest < len(line) && (line[rest] == ' ' || line[rest] == '\t') {
+				rest++
+			}
+			return key, rest
+		}
+	}
+	return line, len(line)
+}
+
+// parseStrings reads iOS .strings file (`"key" = "value";`)
+func parseStrings(content []byte) ([]Entry, error) {
+	text := string(content)
+	entries := make([]Entry, 0)
+	lines := &lineCounter{content: content, line: 1}
+	uncommented := stripStringsComments(text)
+	leftover := []byte(uncommented)
+	for _, match := range stringsEntry.FindAllStringSubmatchIndex(uncommented, -1) {
+		entries = append(entries, Entry{
+			Key:   unescapeStrings(uncommented[match[2]:match[3]]),
+			Value: unescapeStrings(uncommented[match[4]:match[5]]),
+			Line:  lines.at(match[0]),
+			start: match[4],
+			end:   match[5],
+		})
+		for i := match[0]; i < match[1]; i++ {
+			leftover[i] = ' '
+		}
+	}
+	for i, c := range leftover {
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			return nil, &SyntaxError{
+				Line: lineAt(content, i),
+				Msg:  "expected `\"key\" = \"value\";` entry",
+			}
+		}
+	}
+	return entries, nil
+}
+
+// stripStringsComments blanks out `//` and `/* */` comments keeping offsets the same.
+// Comment markers inside quoted keys and values (e.g. URLs) are kept
+func stripStringsComments(text string) string {
+	result := []byte(text)
+	blank := func(from, to int) {
+		for i := from; i < to; i++ {
+			if result[i] != '\n' {
+				result[i] = ' '
+			}
+		}
+	}
+	quoted := false
+	for i := 0; i < len(text); i++ {
+		switch {
+		case quoted && text[i] == '\\':
+			i++
+		case text[i] == '"':
+			quoted = !quoted
+		case quoted:
+		case strings.HasPrefix(text[i:], "//"):
+			end := strings.IndexByte(text[i:], '\n')
+			if end < 0 {
+				end = len(text) - i
+			}
+			blank(i, i+end)
+			i += end
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				// unterminated comment is left as is and reported as syntax error
+				return string(result)
+			}
+			blank(i, i+end+4)
+			i += end + 3
+		}
+	}
+	return string(result)
+}
+
+func unescapeStrings(value string) string {
+	replacer := strings.NewReplacer(`\"`, `"`, `\n`, "\n", `\t`, "\t", `\\`, `\`)
+	return replacer.Replace(value)
+}
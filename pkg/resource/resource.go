@@ -0,0 +1,165 @@
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Format of a resource file
+type Format string
+
+// supported resource formats
+const (
+	JSON       Format = "json"
+	YAML       Format = "yaml"
+	AndroidXML Format = "android-xml"
+	RESX       Format = "resx"
+	Properties Format = "properties"
+	Strings    Format = "strings"
+)
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+)
+
+// Entry is a single key/value pair found in resource file
+type Entry struct {
+	Key   string
+	Value string
+	Line  int
+	// path is a list of raw keys of nested entry, which are joined into Key. Raw keys might contain separator
+	path []string
+	// pluralOf is a key of Android `<plurals>` element, which item is the entry. Languages have different
+	// plural categories, so items are compared by that key
+	pluralOf string
+	// start and end are offsets of raw value in file content. end == 0 means that value can't be replaced in place
+	start int
+	end   int
+}
+
+// rawKey identifies entry in file. Nested `{"a": {"b": ""}}` and flat `{"a.b": ""}` keys have the same Key,
+// but they are different keys of file
+func (e *Entry) rawKey() string {
+	if len(e.path) == 0 {
+		return e.Key
+	}
+	return strings.Join(e.path, "\x00")
+}
+
+// File is a parsed resource file
+type File struct {
+	Path    string
+	Format  Format
+	Entries []Entry
+	content []byte
+}
+
+// UnsupportedFormatError is returned by Parse for files, which format can't be parsed
+type UnsupportedFormatError struct {
+	Path string
+	// Reason explains why file with supported extension can't be parsed
+	Reason string
+}
+
+func (e *UnsupportedFormatError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("file format of '%s' is not supported", e.Path)
+	}
+	return fmt.Sprintf("file format of '%s' is not supported: %s", e.Path, e.Reason)
+}
+
+// SyntaxError describes problem found on file parsing
+type SyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Line == 0 {
+		return e.Msg
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// FormatOf returns resource format by file's extension
+func FormatOf(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return JSON, true
+	case ".yml", ".yaml":
+		return YAML, true
+	case ".xml":
+		return AndroidXML, true
+	case ".resx":
+		return RESX, true
+	case ".properties":
+		return Properties, true
+	case ".strings":
+		return Strings, true
+	}
+	return "", false
+}
+
+// IsSupported checks that file with specified path can be parsed
+func IsSupported(path string) bool {
+	_, ok := FormatOf(path)
+	return ok
+}
+
+// Parse reads all entries from resource file content. Format is detected by file extension
+func Parse(path string, content []byte) (*File, error) {
+	format, ok := FormatOf(path)
+	if !ok {
+		return nil, &UnsupportedFormatError{Path: path}
+	}
+	if bytes.HasPrefix(content, utf16BEBOM) || bytes.HasPrefix(content, utf16LEBOM) {
+		return nil, &SyntaxError{Line: 1, Msg: "file is UTF-16 encoded, only UTF-8 is supported"}
+	}
+	content = bytes.TrimPrefix(content, utf8BOM)
+	file := &File{
+		Path:    path,
+		Format:  format,
+		content: content,
+	}
+	var err error
+	switch format {
+	case JSON:
+		file.Entries, err = parseJSON(content)
+	case YAML:
+		file.Entries, err = parseYAML(content)
+	case AndroidXML, RESX:
+		file.Entries, err = parseXML(content, format)
+	case Properties:
+		file.Entries, err = parseProperties(content)
+	case Strings:
+		file.Entries, err = parseStrings(content)
+	}
+	if err == errNotAndroidResources {
+		return nil, &UnsupportedFormatError{Path: path, Reason: err.Error()}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Values returns map from key to its value. In case of duplicated keys the last value is used
+func (f *File) Values() map[string]string {
+	values := make(map[string]string, len(f.Entries))
+	for _, entry := range f.Entries {
+		values[entry.Key] = entry.Value
+	}
+	return values
+}
+
+// lineAt returns 1-based line number of specified offset in content
+func lineAt(content []byte, offset int) int {
+	if offset > len(content) {
+		offset = len(content)
+	}
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
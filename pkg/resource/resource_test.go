@@ -0,0 +1,142 @@
+package resource
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var (
+	jsonFile = `{
+  "title": "Hello",
+  "menu": {
+    "open": "Open {name}",
+    "items": ["first", "second"]
+  },
+  "count": 5,
+  "empty": null
+}`
+	androidFile = `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <string name="app_name">My App</string>
+    <string name="welcome">Hello, <b>%1$s</b>!</string>
+    <string name="hidden" translatable="false">Hidden</string>
+    <plurals name="songs">
+        <item quantity="one">%d song</item>
+        <item quantity="other">%d songs</item>
+    </plurals>
+</resources>`
+	resxFile = `<?xml version="1.0" encoding="utf-8"?>
+<root>
+  <data name="Title" xml:space="preserve">
+    <value>Main title</value>
+    <comment>Page title</comment>
+  </data>
+  <data name="Icon" type="System.Drawing.Bitmap, System.Drawing">
+    <value>base64</value>
+  </data>
+</root>`
+	yamlFile = `en:
+  title: Hello
+  menu:
+    open: Open
+`
+	propertiesFile = `# comment
+title=Hello
+menu.open = Open \
+  file
+`
+	stringsFile = `/* comment */
+"title" = "Hello";
+// another comment
+"menu.open" = "Open \"file\"";
+`
+)
+
+func TestParseJSON(t *testing.T) {
+	file, err := Parse("en.json", []byte(jsonFile))
+	assert.Nil(t, err)
+	assert.Equal(t, JSON, file.Format)
+	assert.Equal(t, map[string]string{
+		"title":        "Hello",
+		"menu.open":    "Open {name}",
+		"menu.items.0": "first",
+		"menu.items.1": "second",
+		"empty":        "",
+	}, file.Values())
+	assert.Equal(t, 2, file.Entries[0].Line)
+	assert.Equal(t, 4, file.Entries[1].Line)
+}
+
+func TestParseJSONSyntaxError(t *testing.T) {
+	_, err := Parse("en.json", []byte("{\n  \"title\": \"Hello\"\n  \"other\": \"value\"\n}"))
+	syntaxErr, ok := err.(*SyntaxError)
+	assert.True(t, ok)
+	assert.Equal(t, 3, syntaxErr.Line)
+}
+
+func TestParseAndroidXML(t *testing.T) {
+	file, err := Parse("strings.xml", []byte(androidFile))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"app_name":    "My App",
+		"welcome":     "Hello, <b>%1$s</b>!",
+		"songs.one":   "%d song",
+		"songs.other": "%d songs",
+	}, file.Values())
+	assert.Equal(t, 3, file.Entries[0].Line)
+	assert.Equal(t, 8, file.Entries[3].Line)
+}
+
+func TestParseXMLSyntaxError(t *testing.T) {
+	_, err := Parse("strings.xml", []byte("<resources>\n<string name=\"a\">text</strin>\n</resources>"))
+	syntaxErr, ok := err.(*SyntaxError)
+	assert.True(t, ok)
+	assert.Equal(t, 2, syntaxErr.Line)
+}
+
+func TestParseResx(t *testing.T) {
+	file, err := Parse("strings.resx", []byte(resxFile))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"Title": "Main title"}, file.Values())
+	assert.Equal(t, 3, file.Entries[0].Line)
+}
+
+func TestParseYAML(t *testing.T) {
+	file, err := Parse("en.yml", []byte(yamlFile))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"title": "Hello", "menu.open": "Open"}, file.Values())
+	assert.Equal(t, 4, file.Entries[1].Line)
+}
+
+func TestParseProperties(t *testing.T) {
+	file, err := Parse("messages.properties", []byte(propertiesFile))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"title": "Hello", "menu.open": "Open file"}, file.Values())
+	assert.Equal(t, 3, file.Entries[1].Line)
+}
+
+func TestParseStrings(t *testing.T) {
+	file, err := Parse("Localizable.strings", []byte(stringsFile))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"title": "Hello", "menu.open": `Open "file"`}, file.Values())
+	assert.Equal(t, 4, file.Entries[1].Line)
+
+	_, err = Parse("Localizable.strings", []byte(`"title" = "Hello"`))
+	assert.NotNil(t, err)
+}
+
+func TestParseStringsCommentMarkersInValues(t *testing.T) {
+	file, err := Parse("Localizable.strings", []byte(`/* links */
+"url" = "http://example.com"; // homepage
+"glob" = "src/* \"quoted // text\" */";
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"url": "http://example.com", "glob": `src/* "quoted // text" */`}, file.Values())
+	assert.Equal(t, 3, file.Entries[1].Line)
+}
+
+func TestParseUnsupported(t *testing.T) {
+	_, err := Parse("file.docx", []byte(""))
+	assert.NotNil(t, err)
+	assert.False(t, IsSupported("file.docx"))
+}
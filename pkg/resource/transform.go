@@ -0,0 +1,80 @@
+package resource
+
+import (
+	"bytes"
+	"gopkg.in/yaml.v2"
+)
+
+// Transform returns file content with every value replaced by result of `transform`. Values are passed as they are
+// written in file (escaped), so transform has to keep escape sequences untouched. YAML files are re-encoded,
+// so their comments and formatting aren't preserved. Values which can't be replaced in place are left as is
+func (f *File) Transform(transform func(raw string) string) ([]byte, error) {
+	if f.Format == YAML {
+		return transformYAML(f.content, transform)
+	}
+	var result bytes.Buffer
+	offset := 0
+	for _, entry := range f.Entries {
+		if entry.end == 0 || entry.start < offset {
+			continue
+		}
+		raw := string(f.content[entry.start:entry.end])
+		replacement := transformRaw(f.Format, raw, transform)
+		result.Write(f.content[offset:entry.start])
+		result.WriteString(replacement)
+		offset = entry.end
+	}
+	result.Write(f.content[offset:])
+	return result.Bytes(), nil
+}
+
+func transformRaw(format Format, raw string, transform func(raw string) string) string {
+	if format != JSON {
+		return transform(raw)
+	}
+	// JSON span includes quotes of string value or `null` literal
+	if len(raw) < 2 || raw[0] != '"' {
+		return raw
+	}
+	return `"` + transform(raw[1:len(raw)-1]) + `"`
+}
+
+func transformYAML(content []byte, transform func(raw string) string) ([]byte, error) {
+	var document yaml.MapSlice
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, yamlSyntaxError(err)
+	}
+	return yaml.Marshal(transformYAMLValue(document, transform))
+}
+
+// ReplaceLocaleRoot renames Rails-like root locale key (`en:`) of YAML content into locale.
+// Content without such key is returned as is
+func ReplaceLocaleRoot(content []byte, locale string) ([]byte, error) {
+	var document yaml.MapSlice
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, yamlSyntaxError(err)
+	}
+	if _, ok := localeRoot(document); !ok {
+		return content, nil
+	}
+	document[0].Key = locale
+	return yaml.Marshal(document)
+}
+
+func transformYAMLValue(value interface{}, transform func(raw string) string) interface{} {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		for i := range v {
+			v[i].Value = transformYAMLValue(v[i].Value, transform)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = transformYAMLValue(v[i], transform)
+		}
+		return v
+	case string:
+		return transform(v)
+	}
+	return value
+}
@@ -0,0 +1,40 @@
+package resource
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestTransform(t *testing.T) {
+	tests := []struct {
+		path     string
+		content  string
+		expected string
+	}{
+		{"en.json", `{"a": "x", "b": {"c": "y\"z"}, "d": null, "n": 1}`, `{"a": "X", "b": {"c": "Y\"Z"}, "d": null, "n": 1}`},
+		{"strings.xml", `<resources><string name="a">x &amp; y</string><string name="b"/></resources>`,
+			`<resources><string name="a">X &AMP; Y</string><string name="b"/></resources>`},
+		{"en.properties", "# comment\na = x\nb: y\\\n  z\n", "# comment\na = X\nb: y\\\n  z\n"},
+		{"en.strings", `/* c */ "a" = "x";`, `/* c */ "a" = "X";`},
+		{"en.yml", "en:\n  a: x\n  b: [q]\n", "en:\n  a: X\n  b:\n  - Q\n"},
+	}
+	for _, test := range tests {
+		file, err := Parse(test.path, []byte(test.content))
+		assert.Nil(t, err)
+		result, err := file.Transform(strings.ToUpper)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, string(result), test.path)
+	}
+}
+
+func TestReplaceLocaleRoot(t *testing.T) {
+	result, err := ReplaceLocaleRoot([]byte("en:\n  a: x\n"), "en-xa")
+	assert.Nil(t, err)
+	assert.Equal(t, "en-xa:\n  a: x\n", string(result))
+
+	content := []byte("title: x\nmenu: y\n")
+	result, err = ReplaceLocaleRoot(content, "en-xa")
+	assert.Nil(t, err)
+	assert.Equal(t, string(content), string(result))
+}
@@ -0,0 +1,88 @@
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"strconv"
+	"strings"
+)
+
+// Translate returns file content with values replaced by translations found by entry key. Values without
+// translation are kept as is. Like Transform, YAML files are re-encoded
+func (f *File) Translate(translations map[string]string) ([]byte, error) {
+	if f.Format == YAML {
+		return translateYAML(f.content, translations)
+	}
+	var result bytes.Buffer
+	offset := 0
+	for _, entry := range f.Entries {
+		translation, ok := translations[entry.Key]
+		if !ok || entry.end == 0 || entry.start < offset {
+			continue
+		}
+		result.Write(f.content[offset:entry.start])
+		result.WriteString(f.escape(translation, string(f.content[entry.start:entry.end])))
+		offset = entry.end
+	}
+	result.Write(f.content[offset:])
+	return result.Bytes(), nil
+}
+
+// escape returns value escaped for file format. Raw value is an original value how it is written in file
+func (f *File) escape(value, raw string) string {
+	switch f.Format {
+	case JSON:
+		if !strings.HasPrefix(raw, `"`) {
+			return raw
+		}
+		return jsonString(value)
+	case AndroidXML, RESX:
+		return escapeXML(value)
+	case Properties:
+		return escapeProperty(value, false)
+	case Strings:
+		return escapeStrings(value)
+	}
+	return raw
+}
+
+func translateYAML(content []byte, translations map[string]string) ([]byte, error) {
+	var document yaml.MapSlice
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, yamlSyntaxError(err)
+	}
+	var root []string
+	if len(document) == 1 {
+		if _, ok := document[0].Value.(yaml.MapSlice); ok && localeKey.MatchString(fmt.Sprint(document[0].Key)) {
+			// keys of entries don't contain locale root, see stripLocaleRoot
+			root = []string{fmt.Sprint(document[0].Key)}
+		}
+	}
+	return yaml.Marshal(translateYAMLValue(nil, len(root), document, translations))
+}
+
+// translateYAMLValue replaces string values by translations. Skipped is a number of root path elements not
+// included into entry keys
+func translateYAMLValue(path []string, skipped int, value interface{}, translations map[string]string) interface{} {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		for i := range v {
+			v[i].Value = translateYAMLValue(appendPath(path, fmt.Sprint(v[i].Key)), skipped, v[i].Value, translations)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = translateYAMLValue(appendPath(path, strconv.Itoa(i)), skipped, v[i], translations)
+		}
+		return v
+	case string, nil:
+		if skipped > len(path) {
+			return v
+		}
+		if translation, ok := translations[strings.Join(path[skipped:], keySeparator)]; ok {
+			return translation
+		}
+	}
+	return value
+}
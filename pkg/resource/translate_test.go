@@ -0,0 +1,28 @@
+package resource
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		path     string
+		content  string
+		expected string
+	}{
+		{"en.json", `{"a": "x", "b": {"c": "y"}, "d": null}`, `{"a": "Bonjour \"toi\" & moi", "b": {"c": "y"}, "d": null}`},
+		{"strings.xml", `<resources><string name="a">x</string></resources>`, `<resources><string name="a">Bonjour &#34;toi&#34; &amp; moi</string></resources>`},
+		{"en.properties", "a = x\nb = y\n", "a = Bonjour \"toi\" & moi\nb = y\n"},
+		{"en.strings", `"a" = "x";`, `"a" = "Bonjour \"toi\" & moi";`},
+		{"en.yml", "en:\n  a: x\n  b: [q]\n", "en:\n  a: Bonjour \"toi\" & moi\n  b:\n  - q\n"},
+	}
+	translations := map[string]string{"a": `Bonjour "toi" & moi`, "d": "ignored"}
+	for _, test := range tests {
+		file, err := Parse(test.path, []byte(test.content))
+		assert.Nil(t, err)
+		result, err := file.Translate(translations)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, string(result), test.path)
+	}
+}
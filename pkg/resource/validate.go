@@ -0,0 +1,111 @@
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Severity of validation issue
+type Severity int
+
+// validation issues severities
+const (
+	Warning Severity = iota
+	Error
+)
+
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+// Issue is a single problem found on resource file validation
+type Issue struct {
+	Path     string
+	Line     int
+	Severity Severity
+	Message  string
+}
+
+func (i Issue) String() string {
+	if i.Line == 0 {
+		return fmt.Sprintf("%s: %s: %s", i.Path, i.Severity, i.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", i.Path, i.Line, i.Severity, i.Message)
+}
+
+// Validate checks resource file content: encoding, syntax, duplicated keys, empty values and placeholders
+func Validate(path string, content []byte) []Issue {
+	issues := make([]Issue, 0)
+	addIssue := func(line int, severity Severity, format string, v ...interface{}) {
+		issues = append(issues, Issue{
+			Path:     path,
+			Line:     line,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, v...),
+		})
+	}
+	if bytes.HasPrefix(content, utf8BOM) {
+		addIssue(1, Warning, "file starts with UTF-8 byte order mark (BOM)")
+	}
+	if offset := invalidUTF8Offset(content); offset >= 0 && !bytes.HasPrefix(content, utf16LEBOM) && !bytes.HasPrefix(content, utf16BEBOM) {
+		addIssue(lineAt(content, offset), Error, "invalid UTF-8 byte sequence")
+		return issues
+	}
+	file, err := Parse(path, content)
+	if err != nil {
+		if syntaxErr, ok := err.(*SyntaxError); ok {
+			addIssue(syntaxErr.Line, Error, "syntax error: %s", syntaxErr.Msg)
+		} else if _, ok := err.(*UnsupportedFormatError); ok {
+			addIssue(0, Warning, "%v, it isn't validated", err)
+		} else {
+			addIssue(0, Error, "%v", err)
+		}
+		return issues
+	}
+	keyLines := make(map[string]int, len(file.Entries))
+	for _, entry := range file.Entries {
+		if firstLine, ok := keyLines[entry.rawKey()]; ok {
+			addIssue(entry.Line, Error, "duplicate key '%s' (first defined on line %d)", entry.Key, firstLine)
+		} else {
+			keyLines[entry.rawKey()] = entry.Line
+		}
+		if strings.TrimSpace(entry.Value) == "" {
+			addIssue(entry.Line, Warning, "empty value of key '%s'", entry.Key)
+			continue
+		}
+		problems, warnings := PlaceholderProblems(entry.Value)
+		for _, problem := range problems {
+			addIssue(entry.Line, Error, "key '%s': %s", entry.Key, problem)
+		}
+		for _, warning := range warnings {
+			addIssue(entry.Line, Warning, "key '%s': %s", entry.Key, warning)
+		}
+	}
+	return issues
+}
+
+// HasErrors checks if issues contain at least one error. If `strict` is set, warnings are treated as errors
+func HasErrors(issues []Issue, strict bool) bool {
+	for _, issue := range issues {
+		if strict || issue.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidUTF8Offset(content []byte) int {
+	for offset := 0; offset < len(content); {
+		r, size := utf8.DecodeRune(content[offset:])
+		if r == utf8.RuneError && size == 1 {
+			return offset
+		}
+		offset += size
+	}
+	return -1
+}
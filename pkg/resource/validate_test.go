@@ -0,0 +1,71 @@
+package resource
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	content := `{
+  "title": "Hello {name",
+  "empty": "",
+  "title": "Duplicate"
+}`
+	issues := Validate("en.json", []byte(content))
+	assert.Len(t, issues, 3)
+	assert.Equal(t, "en.json:2: error: key 'title': invalid ICU message: missing closing '}' of argument", issues[0].String())
+	assert.Equal(t, Warning, issues[1].Severity)
+	assert.Equal(t, 3, issues[1].Line)
+	assert.Equal(t, "en.json:4: error: duplicate key 'title' (first defined on line 2)", issues[2].String())
+	assert.True(t, HasErrors(issues, false))
+}
+
+func TestValidateEmptyArgument(t *testing.T) {
+	issues := Validate("en.json", []byte(`{"format": "Hello {}"}`))
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "en.json:1: warning: key 'format': empty argument '{}' at position 7", issues[0].String())
+	assert.False(t, HasErrors(issues, false))
+}
+
+func TestValidateEncoding(t *testing.T) {
+	issues := Validate("en.json", append([]byte{0xEF, 0xBB, 0xBF}, `{"title": "Hello"}`...))
+	assert.Len(t, issues, 1)
+	assert.Equal(t, Warning, issues[0].Severity)
+	assert.False(t, HasErrors(issues, false))
+	assert.True(t, HasErrors(issues, true))
+
+	issues = Validate("en.json", []byte("{\n\"title\": \"\xff\"}"))
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "en.json:2: error: invalid UTF-8 byte sequence", issues[0].String())
+
+	issues = Validate("en.json", []byte{0xFF, 0xFE, '{', 0, '}', 0})
+	assert.Len(t, issues, 1)
+	assert.Equal(t, Error, issues[0].Severity)
+}
+
+func TestValidateFlatAndNestedKeys(t *testing.T) {
+	issues := Validate("en.json", []byte(`{"a.b": "Flat", "a": {"b": "Nested"}}`))
+	assert.Empty(t, issues)
+
+	issues = Validate("en.yml", []byte("a:\n  b: One\na.b: Two\n"))
+	assert.Empty(t, issues)
+
+	issues = Validate("en.json", []byte("{\n  \"a\": {\"b\": \"One\"},\n  \"a\": {\"b\": \"Two\"}\n}"))
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "en.json:3: error: duplicate key 'a.b' (first defined on line 2)", issues[0].String())
+}
+
+func TestValidateUnsupportedXML(t *testing.T) {
+	_, err := Parse("layout.xml", []byte("<LinearLayout>\n<string name=\"a\">x</string>\n</LinearLayout>"))
+	assert.EqualError(t, err, "file format of 'layout.xml' is not supported: root element is not <resources>")
+
+	issues := Validate("layout.xml", []byte("<?xml version=\"1.0\"?>\n<LinearLayout/>"))
+	assert.Len(t, issues, 1)
+	assert.Equal(t, Warning, issues[0].Severity)
+}
+
+func TestValidateSyntax(t *testing.T) {
+	issues := Validate("strings.xml", []byte("<resources>\n<string name=\"a\">text</strin>\n</resources>"))
+	assert.Len(t, issues, 1)
+	assert.Equal(t, 2, issues[0].Line)
+}
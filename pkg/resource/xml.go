@@ -0,0 +1,223 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	cdataPrefix = "<![CDATA["
+	cdataSuffix = "]]>"
+)
+
+// errNotAndroidResources is returned for .xml files, which aren't Android `<resources>`
+var errNotAndroidResources = errors.New("root element is not <resources>")
+
+// lineCounter calculates line numbers for monotonically growing offsets without rescanning the content
+type lineCounter struct {
+	content []byte
+	offset  int
+	line    int
+}
+
+func (c *lineCounter) at(offset int) int {
+	if offset > len(c.content) {
+		offset = len(c.content)
+	}
+	if offset < c.offset {
+		c.offset = 0
+		c.line = 1
+	}
+	c.line += bytes.Count(c.content[c.offset:offset], []byte("\n"))
+	c.offset = offset
+	return c.line
+}
+
+// parseXML handles both Android `strings.xml` resources and .NET `resx` files
+func parseXML(content []byte, format Format) ([]Entry, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	lines := &lineCounter{content: content, line: 1}
+	entries := make([]Entry, 0)
+	isRoot := true
+	for {
+		offset := int(decoder.InputOffset())
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, xmlSyntaxError(err, lines, offset)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if isRoot && format == AndroidXML && start.Name.Local != "resources" {
+			return nil, errNotAndroidResources
+		}
+		isRoot = false
+		line := lines.at(offset)
+		var found []Entry
+		if format == RESX {
+			found, err = parseResxElement(decoder, content, start, line)
+		} else {
+			found, err = parseAndroidElement(decoder, content, start, line, lines)
+		}
+		if err != nil {
+			return nil, xmlSyntaxError(err, lines, int(decoder.InputOffset()))
+		}
+		entries = append(entries, found...)
+	}
+}
+
+func parseAndroidElement(decoder *xml.Decoder, content []byte, start xml.StartElement, line int, lines *lineCounter) ([]Entry, error) {
+	name := xmlAttr(start, "name")
+	if name == "" || xmlAttr(start, "translatable") == "false" {
+		return nil, nil
+	}
+	switch start.Name.Local {
+	case "string":
+		entry, err := readXMLValue(decoder, content, name)
+		if err != nil {
+			return nil, err
+		}
+		entry.Line = line
+		return []Entry{entry}, nil
+	case "plurals", "string-array":
+		return readXMLItems(decoder, content, start, name, lines)
+	}
+	return nil, nil
+}
+
+// readXMLItems reads `item` children of plurals and string-array elements
+func readXMLItems(decoder *xml.Decoder, content []byte, parent xml.StartElement, name string, lines *lineCounter) ([]Entry, error) {
+	entries := make([]Entry, 0)
+	index := 0
+	for {
+		offset := int(decoder.InputOffset())
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.EndElement:
+			if t.Name.Local == parent.Name.Local {
+				return entries, nil
+			}
+		case xml.StartElement:
+			key := name + keySeparator + strconv.Itoa(index)
+			quantity := xmlAttr(t, "quantity")
+			if quantity != "" {
+				key = name + keySeparator + quantity
+			}
+			index++
+			entry, err := readXMLValue(decoder, content, key)
+			if err != nil {
+				return nil, err
+			}
+			if quantity != "" && parent.Name.Local == "plurals" {
+				entry.pluralOf = name
+			}
+			entry.Line = lines.at(offset)
+			entries = append(entries, entry)
+		}
+	}
+}
+
+func parseResxElement(decoder *xml.Decoder, content []byte, start xml.StartElement, line int) ([]Entry, error) {
+	name := xmlAttr(start, "name")
+	// resources with `type` or `mimetype` are binary objects and are not translatable
+	if start.Name.Local != "data" || name == "" || xmlAttr(start, "type") != "" || xmlAttr(start, "mimetype") != "" {
+		return nil, nil
+	}
+	entries := make([]Entry, 0, 1)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.EndElement:
+			if t.Name.Local == "data" {
+				return entries, nil
+			}
+		case xml.StartElement:
+			if t.Name.Local != "value" {
+				if err = decoder.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			entry, err := readXMLValue(decoder, content, name)
+			if err != nil {
+				return nil, err
+			}
+			entry.Line = line
+			entries = append(entries, entry)
+		}
+	}
+}
+
+// readXMLValue consumes tokens till the end of current element and returns its raw inner content as a value
+func readXMLValue(decoder *xml.Decoder, content []byte, key string) (Entry, error) {
+	valueStart := int(decoder.InputOffset())
+	valueEnd := valueStart
+	depth := 0
+	for depth >= 0 {
+		valueEnd = int(decoder.InputOffset())
+		token, err := decoder.Token()
+		if err != nil {
+			return Entry{}, err
+		}
+		switch token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+	entry := Entry{
+		Key:   key,
+		Value: xmlText(content[valueStart:valueEnd]),
+		start: valueStart,
+		end:   valueEnd,
+	}
+	if valueStart == valueEnd && bytes.HasSuffix(content[:valueStart], []byte("/>")) {
+		// self-closing element like <string name="key"/> has no place for a value
+		entry.end = 0
+	}
+	return entry, nil
+}
+
+func xmlText(raw []byte) string {
+	text := string(raw)
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, cdataPrefix) && strings.HasSuffix(trimmed, cdataSuffix) {
+		return strings.TrimSuffix(strings.TrimPrefix(trimmed, cdataPrefix), cdataSuffix)
+	}
+	return html.UnescapeString(text)
+}
+
+func xmlAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func xmlSyntaxError(err error, lines *lineCounter, offset int) error {
+	if syntaxErr, ok := err.(*xml.SyntaxError); ok {
+		return &SyntaxError{Line: syntaxErr.Line, Msg: syntaxErr.Msg}
+	}
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return &SyntaxError{Line: lines.at(offset), Msg: "unexpected end of file"}
+	}
+	return &SyntaxError{Line: lines.at(offset), Msg: err.Error()}
+}
@@ -0,0 +1,98 @@
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	yamlErrorLine = regexp.MustCompile(`line (\d+): (.*)`)
+	localeKey     = regexp.MustCompile(`^[a-zA-Z]{2,3}([-_][a-zA-Z0-9]{2,4})?$`)
+)
+
+func parseYAML(content []byte) ([]Entry, error) {
+	var document yaml.MapSlice
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, yamlSyntaxError(err)
+	}
+	entries := make([]Entry, 0)
+	flattenYAML(nil, document, &entries)
+	entries = stripLocaleRoot(document, entries)
+	assignYAMLLines(content, entries)
+	return entries, nil
+}
+
+func flattenYAML(path []string, value interface{}, entries *[]Entry) {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		for _, item := range v {
+			flattenYAML(appendPath(path, fmt.Sprint(item.Key)), item.Value, entries)
+		}
+	case []interface{}:
+		for i, item := range v {
+			flattenYAML(appendPath(path, strconv.Itoa(i)), item, entries)
+		}
+	case string:
+		*entries = append(*entries, Entry{Key: strings.Join(path, keySeparator), Value: v, path: path})
+	case nil:
+		*entries = append(*entries, Entry{Key: strings.Join(path, keySeparator), path: path})
+	}
+}
+
+// stripLocaleRoot removes Rails-like root locale key (`en:`, `fr-FR:`) from entry keys,
+// so source and target files have the same keys
+func stripLocaleRoot(document yaml.MapSlice, entries []Entry) []Entry {
+	root, ok := localeRoot(document)
+	if !ok {
+		return entries
+	}
+	for i := range entries {
+		entries[i].Key = strings.TrimPrefix(entries[i].Key, root+keySeparator)
+	}
+	return entries
+}
+
+// localeRoot returns Rails-like root locale key of document if it's the only key of document
+func localeRoot(document yaml.MapSlice) (string, bool) {
+	if len(document) != 1 {
+		return "", false
+	}
+	root := fmt.Sprint(document[0].Key)
+	if _, ok := document[0].Value.(yaml.MapSlice); !ok || !localeKey.MatchString(root) {
+		return "", false
+	}
+	return root, true
+}
+
+// assignYAMLLines finds lines of entries. yaml.v2 doesn't expose node positions, so lines are looked up
+// by the last key element in the order of entries appearance
+func assignYAMLLines(content []byte, entries []Entry) {
+	lines := bytes.Split(content, []byte("\n"))
+	current := 0
+	for i := range entries {
+		keyParts := strings.Split(entries[i].Key, keySeparator)
+		leaf := keyParts[len(keyParts)-1]
+		for line := current; line < len(lines); line++ {
+			text := strings.TrimLeft(string(lines[line]), " -")
+			if strings.HasPrefix(text, leaf+":") || strings.HasPrefix(text, `"`+leaf+`":`) ||
+				strings.HasPrefix(text, `'`+leaf+`':`) {
+				entries[i].Line = line + 1
+				current = line + 1
+				break
+			}
+		}
+	}
+}
+
+func yamlSyntaxError(err error) error {
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+	if match := yamlErrorLine.FindStringSubmatch(message); match != nil {
+		line, _ := strconv.Atoi(match[1])
+		return &SyntaxError{Line: line, Msg: match[2]}
+	}
+	return &SyntaxError{Msg: message}
+}
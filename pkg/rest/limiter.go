@@ -0,0 +1,158 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// minThrottledRate is the lowest rate limiter slows down to after 429 responses
+	minThrottledRate = 0.5
+	// defaultRetryAfter is a pause after 429 response without `Retry-After` header
+	defaultRetryAfter     = time.Second
+	maxRateLimitedRetries = 3
+)
+
+var (
+	clock = time.Now
+	sleep = time.Sleep
+
+	sharedLimiter     *RateLimiter
+	sharedLimiterOnce sync.Once
+)
+
+// RateLimiter is a token bucket limiting requests per second and concurrent requests.
+// 429 responses pause all requests and halve the rate, successful responses restore it gradually
+type RateLimiter struct {
+	// limit is configured requests per second, zero means no limit
+	limit float64
+	slots chan struct{}
+
+	mutex       sync.Mutex
+	rate        float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// NewRateLimiter creates limiter with requests per second and max concurrent requests. Non-positive values disable limits
+func NewRateLimiter(requestsPerSecond float64, maxConcurrent int) *RateLimiter {
+	limiter := &RateLimiter{
+		limit: requestsPerSecond,
+		rate:  requestsPerSecond,
+		last:  clock(),
+	}
+	if requestsPerSecond > 0 {
+		limiter.tokens = limiter.burst()
+	}
+	if maxConcurrent > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrent)
+	}
+	return limiter
+}
+
+// processLimiter returns limiter shared by all clients of the process. It is configured by the first client.
+// Requests aren't limited by default, but 429 responses still pause all of them
+func processLimiter(requestsPerSecond float64, maxConcurrent int) *RateLimiter {
+	sharedLimiterOnce.Do(func() {
+		sharedLimiter = NewRateLimiter(requestsPerSecond, maxConcurrent)
+	})
+	return sharedLimiter
+}
+
+// Acquire waits for a token and a free concurrent slot. Returned function releases the slot
+func (l *RateLimiter) Acquire() func() {
+	if l.slots != nil {
+		l.slots <- struct{}{}
+	}
+	for delay := l.reserve(); delay > 0; delay = l.reserve() {
+		sleep(delay)
+	}
+	return func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+}
+
+// Throttle pauses all requests for retryAfter and halves the rate
+func (l *RateLimiter) Throttle(retryAfter time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if pausedUntil := clock().Add(retryAfter); pausedUntil.After(l.pausedUntil) {
+		l.pausedUntil = pausedUntil
+	}
+	if l.limit > 0 {
+		l.rate /= 2
+		if l.rate < minThrottledRate {
+			l.rate = minThrottledRate
+		}
+		l.tokens = 0
+	}
+}
+
+// Succeed restores throttled rate by a tenth of configured limit
+func (l *RateLimiter) Succeed() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.rate < l.limit {
+		l.rate += l.limit / 10
+		if l.rate > l.limit {
+			l.rate = l.limit
+		}
+	}
+}
+
+// Rate returns current requests per second
+func (l *RateLimiter) Rate() float64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.rate
+}
+
+// reserve takes a token and returns zero or returns time to wait for the next one
+func (l *RateLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	now := clock()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if l.limit <= 0 {
+		return 0
+	}
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	l.last = now
+	if burst := l.burst(); l.tokens > burst {
+		l.tokens = burst
+	}
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// burst allows to send requests of one second at once
+func (l *RateLimiter) burst() float64 {
+	if l.rate < 1 {
+		return 1
+	}
+	return l.rate
+}
+
+// retryAfter parses `Retry-After` header in seconds or HTTP date
+func retryAfter(response *http.Response) time.Duration {
+	value := response.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(clock()); delay > 0 {
+			return delay
+		}
+	}
+	return defaultRetryAfter
+}
@@ -0,0 +1,107 @@
+package rest
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// stubClock replaces clock and sleep with fake time. Returned function restores them
+func stubClock() (*[]time.Duration, func()) {
+	current := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	sleeps := make([]time.Duration, 0)
+	clock = func() time.Time { return current }
+	sleep = func(d time.Duration) {
+		sleeps = append(sleeps, d)
+		current = current.Add(d)
+	}
+	return &sleeps, func() {
+		clock, sleep = time.Now, time.Sleep
+	}
+}
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	sleeps, restore := stubClock()
+	defer restore()
+	limiter := NewRateLimiter(2, 0)
+	limiter.Acquire()()
+	limiter.Acquire()()
+	assert.Empty(t, *sleeps)
+	limiter.Acquire()()
+	assert.Equal(t, []time.Duration{500 * time.Millisecond}, *sleeps)
+}
+
+func TestRateLimiterThrottle(t *testing.T) {
+	sleeps, restore := stubClock()
+	defer restore()
+	limiter := NewRateLimiter(4, 0)
+	limiter.Throttle(3 * time.Second)
+	assert.Equal(t, 2.0, limiter.Rate())
+	limiter.Acquire()()
+	assert.Equal(t, []time.Duration{3 * time.Second}, *sleeps)
+
+	limiter.Throttle(0)
+	limiter.Throttle(0)
+	limiter.Throttle(0)
+	assert.Equal(t, minThrottledRate, limiter.Rate())
+	for i := 0; i < 20; i++ {
+		limiter.Succeed()
+	}
+	assert.Equal(t, 4.0, limiter.Rate())
+}
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	limiter := NewRateLimiter(0, 1)
+	release := limiter.Acquire()
+	acquired := make(chan struct{})
+	go func() {
+		limiter.Acquire()()
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("second request should wait for free slot")
+	case <-time.After(50 * time.Millisecond):
+	}
+	release()
+	<-acquired
+}
+
+func TestRetryAfter(t *testing.T) {
+	_, restore := stubClock()
+	defer restore()
+	response := &http.Response{Header: http.Header{}}
+	assert.Equal(t, defaultRetryAfter, retryAfter(response))
+	response.Header.Set("Retry-After", "7")
+	assert.Equal(t, 7*time.Second, retryAfter(response))
+	response.Header.Set("Retry-After", clock().Add(time.Minute).Format(http.TimeFormat))
+	assert.Equal(t, time.Minute, retryAfter(response))
+}
+
+func TestClientRetriesRateLimited(t *testing.T) {
+	bodies := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		rw.Write([]byte("OK"))
+	}))
+	defer server.Close()
+	limiter := NewRateLimiter(10, 1)
+	client := &Client{Config: &types.Config{}, HTTPClient: server.Client(), Limiter: limiter}
+
+	response, err := client.PostToServer(server.URL, map[string]string{"key": "value"})
+	assert.Nil(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, []string{`{"key":"value"}`, `{"key":"value"}`}, bodies)
+	assert.True(t, limiter.Rate() < 10)
+}
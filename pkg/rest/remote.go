@@ -3,73 +3,99 @@ package rest
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"io"
 	"io/ioutil"
-	"net"
 	"net/http"
 	"os"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
 const (
 	// ApplicationJSONType used in Http header 'Content-Type'
 	ApplicationJSONType = "application/json"
+	// RequestIDHeader is a header with id of request. The same id is logged in `request_id` field
+	RequestIDHeader = "X-Request-ID"
 )
 
+var requestCounter uint64
+
 // Client implements pkg.Client
 type Client struct {
 	Config     *types.Config
 	HTTPClient *http.Client
+	// Logger logs every request with its id, status and duration
+	Logger log.Logger
+	// Limiter limits requests rate if set. NewClient sets limiter shared by all clients
+	Limiter *RateLimiter
+}
+
+func (r *Client) logger() log.Logger {
+	return log.OrDefault(r.Logger)
 }
 
-// NewRestClient create new instance of RestClient
+// NewRestClient create new instance of RestClient. Exits if network configuration is invalid
 func NewRestClient(qordobaConfig *types.Config) *Client {
-	transport := &http.Transport{
-		DialContext: (&net.Dialer{
-			Timeout:   10 * time.Second,
-			KeepAlive: 10 * time.Second,
-		}).DialContext,
-		TLSHandshakeTimeout: 10 * time.Second,
-		DisableKeepAlives:   true,
+	client, err := NewClient(qordobaConfig)
+	if err != nil {
+		log.Errorf("invalid HTTP configuration: %v", err)
+		os.Exit(1)
+	}
+	return client
+}
+
+// NewClient creates client with proxy, TLS and tracing settings of config and flags
+func NewClient(qordobaConfig *types.Config) (*Client, error) {
+	transport, err := newTransport(qordobaConfig)
+	if err != nil {
+		return nil, err
+	}
+	httpConfig := mergeHTTPConfig(qordobaConfig.HTTP, HTTPFlags)
+	var roundTripper http.RoundTripper = transport
+	if TraceHTTP || TraceFile != "" {
+		roundTripper = &TracingTransport{Transport: transport, WithBodies: TraceBodies, File: TraceFile}
 	}
 	return &Client{
 		HTTPClient: &http.Client{
 			Timeout:   time.Minute * 1,
-			Transport: transport,
+			Transport: roundTripper,
 		},
-		Config: qordobaConfig,
-	}
+		Config:  qordobaConfig,
+		Limiter: processLimiter(httpConfig.RateLimit, httpConfig.MaxConcurrent),
+	}, nil
 }
 
 // GetFromServer - util function for general request to server. Adds x-auth-token from config, validate response
 func (r *Client) GetFromServer(getURL string) ([]byte, error) {
 	request, err := http.NewRequest("GET", getURL, nil)
 	if err != nil {
-		log.Errorf("error occurred on request build: %v", err)
+		r.logger().Errorf("error occurred on request build: %v", err)
 		return nil, err
 	}
 	request.Header.Add("x-auth-token", r.Config.Qordoba.AccessToken)
-	response, err := r.HTTPClient.Do(request)
+	logger := r.requestLogger(request)
+	response, err := r.do(request)
 	if err != nil {
-		log.Errorf("error occurred on GetFromServer request: %v", err)
+		logger.Errorf("error occurred on GetFromServer request: %v", err)
 		return nil, err
 	}
+	defer response.Body.Close()
 	bodyBytes, err := ioutil.ReadAll(response.Body)
 	if err != nil {
 		return nil, fmt.Errorf("error occurred on body read: %v", err)
 	}
 	if response.StatusCode/100 != 2 {
 		if response.StatusCode == http.StatusUnauthorized {
-			log.Errorf("User is not authorised for this request. Check `access_token` in configuration.")
+			r.logger().Errorf("User is not authorised for this request. Check `access_token` in configuration.")
 			os.Exit(1)
 		} else {
-			log.Errorf("Error occurred on get %s request. Status: %v, Response : %v", getURL, response.Status, string(bodyBytes))
+			logger.Errorf("Error occurred on get %s request. Status: %v, Response : %v", getURL, response.Status, string(bodyBytes))
 		}
-		return nil, errors.New("unsuccessful request")
+		return nil, responseError(request, response, bodyBytes)
 	}
 	return bodyBytes, err
 }
@@ -87,7 +113,7 @@ func (r *Client) PostToServer(postURL string, requestBody interface{}) (*http.Re
 	}
 	request.Header.Add("x-auth-token", r.Config.Qordoba.AccessToken)
 	request.Header.Add("Content-Type", ApplicationJSONType)
-	return r.HTTPClient.Do(request)
+	return r.do(request)
 }
 
 // PutToServer send PUT request to server with specified body
@@ -103,7 +129,7 @@ func (r *Client) PutToServer(postURL string, requestBody interface{}) (*http.Res
 	}
 	request.Header.Add("x-auth-token", r.Config.Qordoba.AccessToken)
 	request.Header.Add("Content-Type", ApplicationJSONType)
-	return r.HTTPClient.Do(request)
+	return r.do(request)
 }
 
 func wrapRequest2Reader(requestBody interface{}) (io.Reader, error) {
@@ -120,28 +146,111 @@ func wrapRequest2Reader(requestBody interface{}) (io.Reader, error) {
 func (r *Client) DeleteFromServer(deleteURL string) ([]byte, error) {
 	request, err := http.NewRequest("DELETE", deleteURL, nil)
 	if err != nil {
-		log.Errorf("error occurred on request build: %v", err)
+		r.logger().Errorf("error occurred on request build: %v", err)
 		return nil, err
 	}
 	request.Header.Add("x-auth-token", r.Config.Qordoba.AccessToken)
-	response, err := r.HTTPClient.Do(request)
+	logger := r.requestLogger(request)
+	response, err := r.do(request)
 	if err != nil {
-		log.Errorf("error occurred on DeleteFromServer request: %v", err)
+		logger.Errorf("error occurred on DeleteFromServer request: %v", err)
 		return nil, err
 	}
+	defer response.Body.Close()
 	bodyBytes, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		log.Errorf("error occurred on body read: %v", err)
+		logger.Errorf("error occurred on body read: %v", err)
 		return nil, err
 	}
 	if response.StatusCode/100 != 2 {
 		if response.StatusCode == http.StatusUnauthorized {
-			log.Errorf("User is not authorised for this request. Check `access_token` in configuration.")
+			r.logger().Errorf("User is not authorised for this request. Check `access_token` in configuration.")
 			os.Exit(1)
 		} else {
-			log.Errorf("Error occurred on %s request. Status: %d, Response : %v", deleteURL, response.StatusCode, string(bodyBytes))
+			logger.Errorf("Error occurred on %s request. Status: %d, Response : %v", deleteURL, response.StatusCode, string(bodyBytes))
 		}
-		return nil, errors.New("unsuccessful request")
+		return nil, responseError(request, response, bodyBytes)
 	}
 	return bodyBytes, err
 }
+
+// StatusError is an unsuccessful response of server
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	// Body is a response of server, usually with error description
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s %s: %s", e.Method, e.URL, e.Status)
+	}
+	return fmt.Sprintf("%s %s: %s. Response: %s", e.Method, e.URL, e.Status, e.Body)
+}
+
+// responseError returns status error with response body of unsuccessful request
+func responseError(request *http.Request, response *http.Response, body []byte) error {
+	return &StatusError{
+		Method:     request.Method,
+		URL:        request.URL.String(),
+		StatusCode: response.StatusCode,
+		Status:     response.Status,
+		Body:       strings.TrimSpace(string(body)),
+	}
+}
+
+// do sends request with request id header and logs its duration and status.
+// Rate limited (429) requests are retried after pause of limiter
+func (r *Client) do(request *http.Request) (*http.Response, error) {
+	logger := r.requestLogger(request)
+	for attempt := 1; ; attempt++ {
+		response, err := r.send(request, logger)
+		if err != nil || response.StatusCode != http.StatusTooManyRequests || r.Limiter == nil {
+			return response, err
+		}
+		if attempt > maxRateLimitedRetries || (request.Body != nil && request.GetBody == nil) {
+			return response, nil
+		}
+		delay := retryAfter(response)
+		r.Limiter.Throttle(delay)
+		io.Copy(ioutil.Discard, response.Body)
+		response.Body.Close()
+		logger.Warnf("%s %s is rate limited by server, retry in %v", request.Method, request.URL.Path, delay)
+		if request.GetBody != nil {
+			if request.Body, err = request.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (r *Client) send(request *http.Request, logger log.Logger) (*http.Response, error) {
+	if r.Limiter != nil {
+		release := r.Limiter.Acquire()
+		defer release()
+	}
+	start := time.Now()
+	response, err := r.HTTPClient.Do(request)
+	if err != nil {
+		logger.Debugf("%s %s failed in %v: %v", request.Method, request.URL.Path, time.Since(start), err)
+		return nil, err
+	}
+	logger.Debugf("%s %s: %s in %v", request.Method, request.URL.Path, response.Status, time.Since(start))
+	if r.Limiter != nil && response.StatusCode != http.StatusTooManyRequests {
+		r.Limiter.Succeed()
+	}
+	return response, nil
+}
+
+// requestLogger returns logger with `request_id` field. Request id is generated if request doesn't have one
+func (r *Client) requestLogger(request *http.Request) log.Logger {
+	requestID := request.Header.Get(RequestIDHeader)
+	if requestID == "" {
+		requestID = fmt.Sprintf("%d-%d", os.Getpid(), atomic.AddUint64(&requestCounter, 1))
+		request.Header.Set(RequestIDHeader, requestID)
+	}
+	return r.logger().WithFields(log.Fields{"request_id": requestID})
+}
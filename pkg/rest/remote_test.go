@@ -1,7 +1,9 @@
 package rest
 
 import (
+	"bytes"
 	"encoding/json"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
@@ -95,3 +97,23 @@ func TestClient_DeleteFromServer(t *testing.T) {
 	assert.Equal(t, "DELETE RESPONSE", string(bytesResponse))
 	assert.Nil(t, err)
 }
+
+func TestClientRequestID(t *testing.T) {
+	var buffer bytes.Buffer
+	client := buildClient(t)
+	client.Logger = log.New(log.DebugLevel, log.TextFormat, &buffer)
+	request, err := http.NewRequest("GET", server.URL+"/files", nil)
+	assert.Nil(t, err)
+	request.Header.Set(RequestIDHeader, "test-1")
+	request.Header.Add("x-auth-token", "access-token")
+	response, err := client.do(request)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Contains(t, buffer.String(), "GET /files: 200 OK in ")
+	assert.Contains(t, buffer.String(), " request_id=test-1\n")
+
+	request, err = http.NewRequest("GET", server.URL, nil)
+	assert.Nil(t, err)
+	client.requestLogger(request)
+	assert.NotEmpty(t, request.Header.Get(RequestIDHeader))
+}
@@ -0,0 +1,266 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	redacted     = "REDACTED"
+	maxTraceBody = 64 * 1024
+)
+
+var (
+	// TraceHTTP is a value of `--trace-http` flag
+	TraceHTTP bool
+	// TraceBodies is a value of `--trace-http-bodies` flag
+	TraceBodies bool
+	// TraceFile is a value of `--trace-http-file` flag
+	TraceFile string
+
+	redactedHeaders = map[string]bool{"x-auth-token": true, "authorization": true, "cookie": true, "set-cookie": true}
+)
+
+// TracingTransport logs every request and response and collects them into HAR-like log
+type TracingTransport struct {
+	Transport http.RoundTripper
+	// Logger receives traced requests and responses
+	Logger log.Logger
+	// WithBodies adds request and response bodies (up to 64KB) to the log
+	WithBodies bool
+	// File is created on the first request if set. Every next entry is appended before closing brackets,
+	// so file is a valid HAR even if the process exits
+	File string
+
+	mutex       sync.Mutex
+	entries     []*HAREntry
+	file        *os.File
+	fileEntries int
+}
+
+// HAR is a HTTP archive with traced requests
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog is a content of HTTP archive
+type HARLog struct {
+	Version string      `json:"version"`
+	Creator HARCreator  `json:"creator"`
+	Entries []*HAREntry `json:"entries"`
+}
+
+// HARCreator is an application created HTTP archive
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is a single traced request
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            int64       `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Error           string      `json:"_error,omitempty"`
+}
+
+// HARRequest is a traced request
+type HARRequest struct {
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	Headers  []HARHeader `json:"headers"`
+	BodySize int64       `json:"bodySize"`
+	PostData *HARContent `json:"postData,omitempty"`
+}
+
+// HARResponse is a traced response
+type HARResponse struct {
+	Status     int         `json:"status"`
+	StatusText string      `json:"statusText"`
+	Headers    []HARHeader `json:"headers"`
+	BodySize   int64       `json:"bodySize"`
+	Content    *HARContent `json:"content,omitempty"`
+}
+
+// HARHeader is a header of traced request or response
+type HARHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARContent is a body of traced request or response
+type HARContent struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// RoundTrip sends request with underlying transport and traces it
+func (t *TracingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	entry := &HAREntry{Request: HARRequest{
+		Method:  request.Method,
+		URL:     request.URL.String(),
+		Headers: harHeaders(request.Header),
+	}}
+	requestBody, err := readBody(&request.Body)
+	if err != nil {
+		return nil, err
+	}
+	entry.Request.BodySize = int64(len(requestBody))
+	if t.WithBodies && len(requestBody) > 0 {
+		entry.Request.PostData = harContent(request.Header.Get("Content-Type"), requestBody)
+	}
+	start := time.Now()
+	entry.StartedDateTime = start.Format(time.RFC3339Nano)
+	response, err := t.transport().RoundTrip(request)
+	entry.Time = int64(time.Since(start) / time.Millisecond)
+	logger := t.logger().WithFields(log.Fields{"request_id": request.Header.Get(RequestIDHeader)})
+	if err != nil {
+		entry.Error = err.Error()
+		t.add(entry)
+		logger.Infof("HTTP %s %s failed in %dms: %v", entry.Request.Method, entry.Request.URL, entry.Time, err)
+		return nil, err
+	}
+	responseBody, err := readBody(&response.Body)
+	if err != nil {
+		return nil, err
+	}
+	entry.Response = HARResponse{
+		Status:     response.StatusCode,
+		StatusText: http.StatusText(response.StatusCode),
+		Headers:    harHeaders(response.Header),
+		BodySize:   int64(len(responseBody)),
+	}
+	if t.WithBodies {
+		entry.Response.Content = harContent(response.Header.Get("Content-Type"), responseBody)
+	}
+	t.add(entry)
+	logger.Infof("HTTP %s %s %d %dms request=%dB response=%dB", entry.Request.Method, entry.Request.URL,
+		response.StatusCode, entry.Time, entry.Request.BodySize, entry.Response.BodySize)
+	if entry.Request.PostData != nil {
+		logger.Infof("HTTP request body: %s", entry.Request.PostData.Text)
+	}
+	if entry.Response.Content != nil {
+		logger.Infof("HTTP response body: %s", entry.Response.Content.Text)
+	}
+	return response, nil
+}
+
+// HAR returns all traced requests
+func (t *TracingTransport) HAR() *HAR {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return newHAR(append([]*HAREntry{}, t.entries...))
+}
+
+func newHAR(entries []*HAREntry) *HAR {
+	return &HAR{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: "qor"},
+		Entries: entries,
+	}}
+}
+
+func (t *TracingTransport) add(entry *HAREntry) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.entries = append(t.entries, entry)
+	if t.File == "" {
+		return
+	}
+	if err := t.appendToFile(entry); err != nil {
+		t.logger().Errorf("can't write HTTP trace into %s: %v", t.File, err)
+	}
+}
+
+// appendToFile writes entry over the closing `]}}` of HAR file and restores them after it
+func (t *TracingTransport) appendToFile(entry *HAREntry) error {
+	header, err := json.Marshal(newHAR([]*HAREntry{}))
+	if err != nil {
+		return err
+	}
+	entriesEnd := bytes.LastIndexByte(header, ']')
+	closing := header[entriesEnd:]
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if t.file == nil {
+		if t.file, err = os.Create(t.File); err != nil {
+			return err
+		}
+		if _, err = t.file.Write(header[:entriesEnd]); err != nil {
+			return err
+		}
+	} else if _, err = t.file.Seek(-int64(len(closing)), io.SeekEnd); err != nil {
+		return err
+	}
+	if t.fileEntries > 0 {
+		content = append([]byte(","), content...)
+	}
+	if _, err = t.file.Write(append(append([]byte("\n"), content...), closing...)); err != nil {
+		return err
+	}
+	t.fileEntries++
+	return nil
+}
+
+func (t *TracingTransport) transport() http.RoundTripper {
+	if t.Transport == nil {
+		return http.DefaultTransport
+	}
+	return t.Transport
+}
+
+func (t *TracingTransport) logger() log.Logger {
+	return log.OrDefault(t.Logger)
+}
+
+// readBody reads body and replaces it with a copy, so it still might be read by client or server
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	content, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, fmt.Errorf("error occurred on body read: %v", err)
+	}
+	*body = ioutil.NopCloser(bytes.NewReader(content))
+	return content, nil
+}
+
+// harHeaders returns sorted headers with redacted credentials
+func harHeaders(header http.Header) []HARHeader {
+	headers := make([]HARHeader, 0, len(header))
+	for name, values := range header {
+		for _, value := range values {
+			if redactedHeaders[strings.ToLower(name)] {
+				value = redacted
+			}
+			headers = append(headers, HARHeader{Name: name, Value: value})
+		}
+	}
+	sort.Slice(headers, func(i, j int) bool {
+		return headers[i].Name < headers[j].Name
+	})
+	return headers
+}
+
+func harContent(mimeType string, body []byte) *HARContent {
+	text := string(body)
+	if len(body) > maxTraceBody {
+		text = string(body[:maxTraceBody]) + "...(truncated)"
+	}
+	return &HARContent{MimeType: mimeType, Text: text}
+}
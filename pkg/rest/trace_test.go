@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTracingTransport(t *testing.T) {
+	traceServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"key":"/title"}`, string(body))
+		rw.Header().Set("Content-Type", ApplicationJSONType)
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write([]byte(`{"id":1}`))
+	}))
+	defer traceServer.Close()
+	dir, err := ioutil.TempDir("", "trace")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	var buffer bytes.Buffer
+	transport := &TracingTransport{
+		Transport:  traceServer.Client().Transport,
+		Logger:     log.New(log.InfoLevel, log.TextFormat, &buffer),
+		WithBodies: true,
+		File:       filepath.Join(dir, "qor.har"),
+	}
+	client := &Client{
+		Config:     &types.Config{Qordoba: types.QordobaConfig{AccessToken: "secret-token"}},
+		HTTPClient: &http.Client{Transport: transport},
+	}
+	response, err := client.PostToServer(traceServer.URL+"/keys", map[string]string{"key": "/title"})
+	assert.Nil(t, err)
+	body, err := ioutil.ReadAll(response.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id":1}`, string(body))
+
+	assert.Contains(t, buffer.String(), "HTTP POST "+traceServer.URL+"/keys 201 ")
+	assert.Contains(t, buffer.String(), "request=16B response=8B request_id=")
+	assert.Contains(t, buffer.String(), `HTTP response body: {"id":1}`)
+	assert.NotContains(t, buffer.String(), "secret-token")
+
+	content, err := ioutil.ReadFile(transport.File)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(content), "secret-token")
+	var har HAR
+	assert.Nil(t, json.Unmarshal(content, &har))
+	assert.Len(t, har.Log.Entries, 1)
+	entry := har.Log.Entries[0]
+	assert.Equal(t, http.StatusCreated, entry.Response.Status)
+	assert.Equal(t, `{"key":"/title"}`, entry.Request.PostData.Text)
+	assert.Contains(t, entry.Request.Headers, HARHeader{Name: "X-Auth-Token", Value: redacted})
+
+	_, err = client.PostToServer(traceServer.URL+"/keys", map[string]string{"key": "/title"})
+	assert.Nil(t, err)
+	content, err = ioutil.ReadFile(transport.File)
+	assert.Nil(t, err)
+	har = HAR{}
+	assert.Nil(t, json.Unmarshal(content, &har))
+	assert.Len(t, har.Log.Entries, 2)
+	assert.Equal(t, "qor", har.Log.Creator.Name)
+}
+
+func TestHARContent(t *testing.T) {
+	content := harContent("text/plain", bytes.Repeat([]byte("a"), maxTraceBody+1))
+	assert.Len(t, content.Text, maxTraceBody+len("...(truncated)"))
+}
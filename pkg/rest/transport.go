@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultMaxIdleConns    = 100
+	defaultIdleConnTimeout = 90
+)
+
+// HTTPFlags are values of `--proxy`, `--ca-bundle`, `--client-cert`, `--client-key`, `--insecure-skip-verify`,
+// `--rate-limit` and `--max-concurrent` flags.
+// Non-empty values override `http` section of config
+var HTTPFlags types.HTTPConfig
+
+// newTransport builds transport with proxy and TLS settings of config
+func newTransport(config *types.Config) (*http.Transport, error) {
+	httpConfig := mergeHTTPConfig(config.HTTP, HTTPFlags)
+	proxy, err := proxyFunc(httpConfig.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := newTLSConfig(httpConfig)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig.InsecureSkipVerify {
+		if config.IsProduction() {
+			return nil, errors.New("insecure_skip_verify is allowed only with custom `base_url`")
+		}
+		log.Warnf("TLS certificate verification is disabled for %s", config.GetAPIBase())
+	}
+	maxIdleConns, idleConnTimeout := httpConfig.MaxIdleConns, httpConfig.IdleConnTimeout
+	if maxIdleConns <= 0 {
+		maxIdleConns = defaultMaxIdleConns
+	}
+	if idleConnTimeout <= 0 {
+		idleConnTimeout = defaultIdleConnTimeout
+	}
+	transport := &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: 10 * time.Second,
+		// all requests go to the same API host, so the whole pool might be used by it
+		MaxIdleConns:        maxIdleConns,
+		MaxIdleConnsPerHost: maxIdleConns,
+		IdleConnTimeout:     time.Duration(idleConnTimeout) * time.Second,
+		DisableKeepAlives:   httpConfig.DisableKeepAlives,
+		// transport requests gzip and decompresses responses transparently
+		DisableCompression: false,
+		ForceAttemptHTTP2:  !httpConfig.DisableHTTP2,
+	}
+	if httpConfig.DisableHTTP2 {
+		// non-nil empty map disables HTTP/2 upgrade
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return transport, nil
+}
+
+func mergeHTTPConfig(config, flags types.HTTPConfig) types.HTTPConfig {
+	if flags.Proxy != "" {
+		config.Proxy = flags.Proxy
+	}
+	if flags.CABundle != "" {
+		config.CABundle = flags.CABundle
+	}
+	if flags.ClientCert != "" {
+		config.ClientCert = flags.ClientCert
+	}
+	if flags.ClientKey != "" {
+		config.ClientKey = flags.ClientKey
+	}
+	if flags.RateLimit != 0 {
+		config.RateLimit = flags.RateLimit
+	}
+	if flags.MaxConcurrent != 0 {
+		config.MaxConcurrent = flags.MaxConcurrent
+	}
+	config.InsecureSkipVerify = config.InsecureSkipVerify || flags.InsecureSkipVerify
+	return config
+}
+
+func proxyFunc(proxy string) (func(*http.Request) (*url.URL, error), error) {
+	if proxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL '%s'", proxy)
+	}
+	if proxyURL.Scheme != "http" && proxyURL.Scheme != "https" && proxyURL.Scheme != "socks5" {
+		return nil, fmt.Errorf("invalid proxy URL '%s'; scheme should be one of: http, https, socks5", proxy)
+	}
+	return http.ProxyURL(proxyURL), nil
+}
+
+func newTLSConfig(httpConfig types.HTTPConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: httpConfig.InsecureSkipVerify}
+	if httpConfig.CABundle != "" {
+		content, err := ioutil.ReadFile(httpConfig.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("can't read CA bundle: %v", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(content) {
+			return nil, fmt.Errorf("CA bundle %s doesn't contain PEM certificates", httpConfig.CABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if (httpConfig.ClientCert == "") != (httpConfig.ClientKey == "") {
+		return nil, errors.New("both client_cert and client_key should be set for client certificate authentication")
+	}
+	if httpConfig.ClientCert != "" {
+		certificate, err := tls.LoadX509KeyPair(httpConfig.ClientCert, httpConfig.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("can't load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,213 @@
+package rest
+
+import (
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMergeHTTPConfig(t *testing.T) {
+	config := types.HTTPConfig{Proxy: "http://config:3128", CABundle: "ca.pem"}
+	merged := mergeHTTPConfig(config, types.HTTPConfig{Proxy: "http://flag:3128", InsecureSkipVerify: true})
+	assert.Equal(t, types.HTTPConfig{Proxy: "http://flag:3128", CABundle: "ca.pem", InsecureSkipVerify: true}, merged)
+}
+
+func TestProxyFunc(t *testing.T) {
+	proxy, err := proxyFunc("http://proxy.corp:3128")
+	assert.Nil(t, err)
+	request, _ := http.NewRequest("GET", "https://app.qordoba.com/v3", nil)
+	proxyURL, err := proxy(request)
+	assert.Nil(t, err)
+	assert.Equal(t, "proxy.corp:3128", proxyURL.Host)
+
+	_, err = proxyFunc("ftp://proxy.corp")
+	assert.EqualError(t, err, "invalid proxy URL 'ftp://proxy.corp'; scheme should be one of: http, https, socks5")
+	_, err = proxyFunc("proxy.corp")
+	assert.NotNil(t, err)
+}
+
+func TestNewTransportInsecure(t *testing.T) {
+	defer func() { HTTPFlags = types.HTTPConfig{} }()
+	HTTPFlags.InsecureSkipVerify = true
+	_, err := newTransport(&types.Config{})
+	assert.EqualError(t, err, "insecure_skip_verify is allowed only with custom `base_url`")
+	transport, err := newTransport(&types.Config{BaseURL: "https://staging.qordoba.com/"})
+	assert.Nil(t, err)
+	assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+}
+
+func TestNewTLSConfigCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte("OK"))
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "tls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	caBundle := filepath.Join(dir, "ca.pem")
+	writePEM(t, caBundle, "CERTIFICATE", server.Certificate().Raw)
+
+	transport, err := newTransport(&types.Config{BaseURL: server.URL, HTTP: types.HTTPConfig{CABundle: caBundle}})
+	assert.Nil(t, err)
+	response, err := (&http.Client{Transport: transport}).Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	_, err = newTLSConfig(types.HTTPConfig{CABundle: filepath.Join(dir, "missing.pem")})
+	assert.NotNil(t, err)
+	_, err = newTLSConfig(types.HTTPConfig{ClientCert: caBundle})
+	assert.EqualError(t, err, "both client_cert and client_key should be set for client certificate authentication")
+}
+
+func TestNewTLSConfigClientCert(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "qor"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key")
+	writePEM(t, certFile, "CERTIFICATE", certificate)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyBytes)
+
+	tlsConfig, err := newTLSConfig(types.HTTPConfig{ClientCert: certFile, ClientKey: keyFile})
+	assert.Nil(t, err)
+	assert.Len(t, tlsConfig.Certificates, 1)
+}
+
+func writePEM(t *testing.T, path, blockType string, content []byte) {
+	err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: content}), 0600)
+	assert.Nil(t, err)
+}
+
+func TestNewTransportConnectionReuse(t *testing.T) {
+	var newConnections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte("OK"))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&newConnections, 1)
+		}
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	client := newTestClient(t, server, types.HTTPConfig{})
+	for i := 0; i < 10; i++ {
+		_, err := client.GetFromServer(server.URL)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&newConnections))
+}
+
+func TestNewTransportHTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(req.Proto))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	proto, err := newTestClient(t, server, types.HTTPConfig{}).GetFromServer(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "HTTP/2.0", string(proto))
+	proto, err = newTestClient(t, server, types.HTTPConfig{DisableHTTP2: true}).GetFromServer(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "HTTP/1.1", string(proto))
+}
+
+func TestNewTransportGzip(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !strings.Contains(req.Header.Get("Accept-Encoding"), "gzip") {
+			rw.Write([]byte("plain"))
+			return
+		}
+		rw.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(rw)
+		writer.Write([]byte("compressed"))
+		writer.Close()
+	}))
+	defer server.Close()
+
+	body, err := newTestClient(t, server, types.HTTPConfig{}).GetFromServer(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "compressed", string(body))
+}
+
+func TestNewTransportIdleLimits(t *testing.T) {
+	transport, err := newTransport(&types.Config{})
+	assert.Nil(t, err)
+	assert.False(t, transport.DisableKeepAlives)
+	assert.Equal(t, 100, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 90*time.Second, transport.IdleConnTimeout)
+
+	transport, err = newTransport(&types.Config{HTTP: types.HTTPConfig{MaxIdleConns: 4, IdleConnTimeout: 5}})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, transport.MaxIdleConns)
+	assert.Equal(t, 4, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 5*time.Second, transport.IdleConnTimeout)
+}
+
+// BenchmarkGetFromServerKeepAlive and BenchmarkGetFromServerNoKeepAlive compare pooled connections
+// with a new TLS handshake on every request
+func BenchmarkGetFromServerKeepAlive(b *testing.B) {
+	benchmarkGetFromServer(b, types.HTTPConfig{})
+}
+
+func BenchmarkGetFromServerNoKeepAlive(b *testing.B) {
+	benchmarkGetFromServer(b, types.HTTPConfig{DisableKeepAlives: true})
+}
+
+func benchmarkGetFromServer(b *testing.B, httpConfig types.HTTPConfig) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"segments":[]}`))
+	}))
+	defer server.Close()
+	client := newTestClient(b, server, httpConfig)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := client.GetFromServer(server.URL); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func newTestClient(t testing.TB, server *httptest.Server, httpConfig types.HTTPConfig) *Client {
+	httpConfig.InsecureSkipVerify = true
+	client, err := NewClient(&types.Config{BaseURL: server.URL, HTTP: httpConfig})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Limiter = nil
+	return client
+}
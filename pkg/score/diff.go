@@ -0,0 +1,55 @@
+package score
+
+// Change is a difference of file version score. Empty category is a document score
+type Change struct {
+	File           string  `json:"file"`
+	Version        string  `json:"version"`
+	Category       string  `json:"category"`
+	Previous       float64 `json:"previous"`
+	Current        float64 `json:"current"`
+	PreviousIssues int     `json:"previous_issues"`
+	CurrentIssues  int     `json:"current_issues"`
+	IsRegression   bool    `json:"regression"`
+}
+
+// Diff compares current records with previous ones. Score decrease or issue count growth is a regression.
+// File versions without previous record are skipped
+func Diff(previous, current []Record) []Change {
+	previousByKey := make(map[string]*Record, len(previous))
+	for i := range previous {
+		previousByKey[previous[i].key()] = &previous[i]
+	}
+	changes := make([]Change, 0)
+	for _, record := range current {
+		before, ok := previousByKey[record.key()]
+		if !ok {
+			continue
+		}
+		changes = append(changes, newChange(&record, "", before.Score, record.Score, 0, 0))
+		for _, breakdown := range record.Breakdown {
+			if !breakdown.Enabled {
+				continue
+			}
+			for _, previousBreakdown := range before.Breakdown {
+				if previousBreakdown.Category == breakdown.Category && previousBreakdown.Enabled {
+					changes = append(changes, newChange(&record, breakdown.Category, previousBreakdown.Score, breakdown.Score,
+						previousBreakdown.IssueCount, breakdown.IssueCount))
+				}
+			}
+		}
+	}
+	return changes
+}
+
+func newChange(record *Record, category string, previous, current float64, previousIssues, currentIssues int) Change {
+	return Change{
+		File:           record.File,
+		Version:        record.Version,
+		Category:       category,
+		Previous:       previous,
+		Current:        current,
+		PreviousIssues: previousIssues,
+		CurrentIssues:  currentIssues,
+		IsRegression:   current < previous || currentIssues > previousIssues,
+	}
+}
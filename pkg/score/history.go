@@ -0,0 +1,122 @@
+package score
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Record is a score of file version at server's snapshot time
+type Record struct {
+	SnapshotTime int64             `json:"snapshot_time"`
+	File         string            `json:"file"`
+	Version      string            `json:"version"`
+	Score        float64           `json:"score"`
+	Breakdown    []types.Breakdown `json:"breakdown"`
+}
+
+func (r *Record) key() string {
+	return r.File + "\x00" + r.Version
+}
+
+// Append adds records to the end of history file, one JSON document per line
+func Append(path string, records []Record) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return err
+		}
+	}
+	historyFile, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer historyFile.Close()
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if _, err = historyFile.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read loads all records of history file
+func Read(path string) ([]Record, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(content)
+}
+
+// Parse parses history file content
+func Parse(content []byte) ([]Record, error) {
+	records := make([]Record, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// Latest returns the latest record of every file version sorted by file name and version
+func Latest(records []Record) []Record {
+	return lastOf(records, 0)
+}
+
+// Previous returns the record preceding the latest one of every file version
+func Previous(records []Record) []Record {
+	return lastOf(records, 1)
+}
+
+// Until returns the latest record of every file version recorded not later than snapshot time
+func Until(records []Record, snapshotTime int64) []Record {
+	filtered := make([]Record, 0, len(records))
+	for _, record := range records {
+		if record.SnapshotTime <= snapshotTime {
+			filtered = append(filtered, record)
+		}
+	}
+	return Latest(filtered)
+}
+
+// lastOf returns record of every file version with given offset from the end of its history
+func lastOf(records []Record, offset int) []Record {
+	byKey := make(map[string][]Record)
+	for _, record := range records {
+		byKey[record.key()] = append(byKey[record.key()], record)
+	}
+	result := make([]Record, 0, len(byKey))
+	for _, history := range byKey {
+		sort.SliceStable(history, func(i, j int) bool {
+			return history[i].SnapshotTime < history[j].SnapshotTime
+		})
+		if len(history) > offset {
+			result = append(result, history[len(history)-1-offset])
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].File == result[j].File {
+			return result[i].Version < result[j].Version
+		}
+		return result[i].File < result[j].File
+	})
+	return result
+}
@@ -0,0 +1,58 @@
+package score
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var history = []Record{
+	{SnapshotTime: 100, File: "en.json", Score: 8, Breakdown: []types.Breakdown{{Category: "Grammar", Score: 8, IssueCount: 1, Enabled: true}}},
+	{SnapshotTime: 300, File: "en.json", Score: 7, Breakdown: []types.Breakdown{{Category: "Grammar", Score: 7, IssueCount: 3, Enabled: true}}},
+	{SnapshotTime: 200, File: "en.json", Score: 9, Breakdown: []types.Breakdown{{Category: "Grammar", Score: 9, IssueCount: 0, Enabled: true}}},
+	{SnapshotTime: 150, File: "app.yaml", Version: "v1", Score: 6},
+}
+
+func TestAppendRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "score")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "history", "score.jsonl")
+	assert.Nil(t, Append(path, history[:2]))
+	assert.Nil(t, Append(path, history[2:]))
+	records, err := Read(path)
+	assert.Nil(t, err)
+	assert.Equal(t, history, records)
+
+	_, err = Parse([]byte("{\"file\": \"en.json\"}\n\nnot json\n"))
+	assert.EqualError(t, err, "line 3: invalid character 'o' in literal null (expecting 'u')")
+}
+
+func TestLatestPrevious(t *testing.T) {
+	latest := Latest(history)
+	assert.Len(t, latest, 2)
+	assert.Equal(t, "app.yaml", latest[0].File)
+	assert.Equal(t, int64(300), latest[1].SnapshotTime)
+
+	previous := Previous(history)
+	assert.Len(t, previous, 1)
+	assert.Equal(t, int64(200), previous[0].SnapshotTime)
+
+	until := Until(history, 199)
+	assert.Equal(t, int64(150), until[0].SnapshotTime)
+	assert.Equal(t, int64(100), until[1].SnapshotTime)
+}
+
+func TestDiff(t *testing.T) {
+	changes := Diff(Previous(history), Latest(history))
+	assert.Equal(t, []Change{
+		{File: "en.json", Previous: 9, Current: 7, IsRegression: true},
+		{File: "en.json", Category: "Grammar", Previous: 9, Current: 7, PreviousIssues: 0, CurrentIssues: 3, IsRegression: true},
+	}, changes)
+
+	changes = Diff(Until(history, 100), Until(history, 200))
+	assert.False(t, changes[0].IsRegression)
+}
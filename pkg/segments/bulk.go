@@ -0,0 +1,116 @@
+package segments
+
+import (
+	"errors"
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"strings"
+)
+
+var (
+	errFileNotFound    = errors.New("file was not found")
+	errSegmentNotFound = errors.New("segment was not found")
+)
+
+// AddKeys adds several keys into file. File is resolved once, failed keys don't stop the rest
+func (s *SegmentService) AddKeys(fileName, version string, keyAddRequests []*types.KeyAddRequest) []*types.KeyResult {
+	file, _ := s.FileService.FindFile(fileName, version, false)
+	results := make([]*types.KeyResult, 0, len(keyAddRequests))
+	for _, keyAddRequest := range keyAddRequests {
+		key, err := s.normalizeSegmentKey(keyAddRequest.Key)
+		if err == nil {
+			keyAddRequest.Key = key
+			if file == nil {
+				err = errFileNotFound
+			} else {
+				err = s.addFileKey(file, keyAddRequest)
+			}
+		}
+		results = append(results, keyResult(keyAddRequest.Key, err))
+	}
+	return results
+}
+
+// UpdateKeys updates values of several keys like UpdateKey does. File segments are loaded once
+func (s *SegmentService) UpdateKeys(fileName, version string, keyAddRequests []*types.KeyAddRequest, options *types.ValueUpdateOptions) []*types.KeyResult {
+	file, segments := s.fileSegments(fileName, version)
+	results := make([]*types.KeyResult, 0, len(keyAddRequests))
+	for _, keyAddRequest := range keyAddRequests {
+		segment, err := s.findCachedSegment(file, segments, keyAddRequest.Key)
+		var personas []types.Person
+		var isTargetUpdate bool
+		if err == nil {
+			personas, isTargetUpdate, err = updatedPersonas(segment, options)
+		}
+		if err == nil {
+			failedCodes := make([]string, 0)
+			for _, persona := range personas {
+				if updateErr := s.updatePersonaSegment(isTargetUpdate, file, segment, persona, keyAddRequest.Source, options); updateErr != nil {
+					s.logger().Debugf("update of %s for %s failed: %v", keyAddRequest.Key, persona.Code, updateErr)
+					failedCodes = append(failedCodes, persona.Code)
+				}
+			}
+			if len(failedCodes) > 0 {
+				err = fmt.Errorf("update failed for %s", strings.Join(failedCodes, ", "))
+			}
+		}
+		results = append(results, keyResult(keyAddRequest.Key, err))
+	}
+	return results
+}
+
+// DeleteKeys deletes several keys from file. File segments are loaded once
+func (s *SegmentService) DeleteKeys(fileName, version string, segmentKeys []string) []*types.KeyResult {
+	file, segments := s.fileSegments(fileName, version)
+	results := make([]*types.KeyResult, 0, len(segmentKeys))
+	for _, segmentKey := range segmentKeys {
+		segment, err := s.findCachedSegment(file, segments, segmentKey)
+		if err == nil {
+			err = s.deleteFileSegment(file, segment)
+		}
+		results = append(results, keyResult(segmentKey, err))
+	}
+	return results
+}
+
+func keyResult(key string, err error) *types.KeyResult {
+	result := &types.KeyResult{Key: key}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (s *SegmentService) findCachedSegment(file *types.File, segments map[string]*types.Segment, segmentKey string) (*types.Segment, error) {
+	key, err := s.normalizeSegmentKey(segmentKey)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, errFileNotFound
+	}
+	segment, ok := segments[key]
+	if !ok {
+		return nil, errSegmentNotFound
+	}
+	return segment, nil
+}
+
+// fileSegments resolves file and loads all its segments once
+func (s *SegmentService) fileSegments(fileName, version string) (*types.File, map[string]*types.Segment) {
+	segments := make(map[string]*types.Segment)
+	file, personaID := s.FileService.FindFile(fileName, version, false)
+	if file == nil {
+		return nil, segments
+	}
+	segmentList, err := s.ListSegments(file, personaID)
+	if err != nil {
+		s.logger().Errorf("error occurred on segments load: %v", err)
+		return file, segments
+	}
+	for i := range segmentList {
+		segments[segmentList[i].StringKey] = &segmentList[i]
+	}
+	s.logger().Debugf("%d segments of %s were loaded", len(segments), fileName)
+	return file, segments
+}
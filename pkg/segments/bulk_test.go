@@ -0,0 +1,73 @@
+package segments
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func okResponse() *http.Response {
+	return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(""))}
+}
+
+func TestSegmentService_AddKeys(t *testing.T) {
+	service := startSegmentService(t)
+	qordobaClient.EXPECT().PostToServer(gomock.Any(), gomock.Any()).Return(okResponse(), nil)
+	qordobaClient.EXPECT().PostToServer(gomock.Any(), gomock.Any()).
+		Return(&http.Response{StatusCode: http.StatusNotAcceptable, Body: ioutil.NopCloser(strings.NewReader(""))}, nil)
+	results := service.AddKeys("config.yaml", "v1", []*types.KeyAddRequest{
+		{Key: "/first", Source: "First"},
+		{Key: "/second", Source: "Second"},
+		{Key: "", Source: "Empty"},
+	})
+	assert.Equal(t, []*types.KeyResult{
+		{Key: "/first"},
+		{Key: "/second", Error: "Key already exist"},
+		{Key: "", Error: "key '' is empty"},
+	}, results)
+}
+
+func TestSegmentService_AddKeysFileNotFound(t *testing.T) {
+	service := startSegmentService(t)
+	results := service.AddKeys("config.yaml", "v2", []*types.KeyAddRequest{{Key: "/first", Source: "First"}})
+	assert.Equal(t, []*types.KeyResult{{Key: "/first", Error: "file was not found"}}, results)
+}
+
+func TestSegmentService_DeleteKeys(t *testing.T) {
+	service := startSegmentService(t)
+	// segments are loaded once for all keys: one page for each of 3 workflow steps
+	qordobaClient.EXPECT().DeleteFromServer(gomock.Any()).Return(nil, nil)
+	results := service.DeleteKeys("config.yaml", "v1", []string{"/some-key", "/absent-key"})
+	assert.Equal(t, []*types.KeyResult{
+		{Key: "/some-key"},
+		{Key: "/absent-key", Error: "segment was not found"},
+	}, results)
+}
+
+func TestSegmentService_UpdateKeys(t *testing.T) {
+	service := startSegmentService(t)
+	qordobaClient.EXPECT().PutToServer(gomock.Any(), gomock.Any()).Times(5).Return(okResponse(), nil)
+	results := service.UpdateKeys("config.yaml", "v1", []*types.KeyAddRequest{{Key: "/some-key", Source: "New"}}, nil)
+	assert.Equal(t, []*types.KeyResult{{Key: "/some-key"}}, results)
+}
+
+func TestSegmentService_UpdateKeysTarget(t *testing.T) {
+	service := startSegmentService(t)
+	qordobaClient.EXPECT().PutToServer(gomock.Any(), gomock.Any()).Times(2).Return(okResponse(), nil)
+	results := service.UpdateKeys("config.yaml", "v1", []*types.KeyAddRequest{
+		{Key: "/some-key", Source: "Nouveau"},
+	}, &types.ValueUpdateOptions{Audiences: []string{"fr-fr", "es-es"}})
+	assert.Equal(t, []*types.KeyResult{{Key: "/some-key"}}, results)
+}
+
+func TestSegmentService_UpdateKeysUnknownTarget(t *testing.T) {
+	service := startSegmentService(t)
+	results := service.UpdateKeys("config.yaml", "v1", []*types.KeyAddRequest{
+		{Key: "/some-key", Source: "Neu"},
+	}, &types.ValueUpdateOptions{Audiences: []string{"de-de"}})
+	assert.Contains(t, results[0].Error, "`de-de` does not match")
+}
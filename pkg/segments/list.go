@@ -0,0 +1,56 @@
+package segments
+
+import (
+	"github.com/qordobacode/cli-v2/pkg/api"
+	"github.com/qordobacode/cli-v2/pkg/types"
+)
+
+const segmentsPageSize = 100
+
+// ListSegments loads all segments of file for persona page by page from every workflow step.
+// Segment's `Workflow` is a name of workflow step where segment is placed. Steps failed to load are logged and skipped,
+// error is returned only if none of them was loaded
+func (s *SegmentService) ListSegments(file *types.File, personaID int) ([]types.Segment, error) {
+	workspaceData, err := s.WorkspaceService.LoadWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]types.Segment, 0)
+	loaded := 0
+	var lastErr error
+	for _, workflow := range workspaceData.Workflow {
+		workflowSegments, err := s.listWorkflowSegments(file, personaID, workflow.ID, "")
+		if err != nil {
+			s.logger().Errorf("error occurred on segments load from workflow step %s: %v", workflow.Name, err)
+			lastErr = err
+			continue
+		}
+		loaded++
+		for i := range workflowSegments {
+			workflowSegments[i].Personas = workspaceData.Workspace.TargetPersonas
+			workflowSegments[i].Workflow = workflow.Name
+		}
+		segments = append(segments, workflowSegments...)
+	}
+	if loaded == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return segments, nil
+}
+
+// listWorkflowSegments loads segments of workflow step page by page. Only segments matching non-empty search are loaded
+func (s *SegmentService) listWorkflowSegments(file *types.File, personaID, workflowID int, search string) ([]types.Segment, error) {
+	segments := make([]types.Segment, 0)
+	for offset := 0; ; offset += segmentsPageSize {
+		query := api.SegmentQuery{Search: search, Limit: segmentsPageSize, Offset: offset}
+		segmentSearchResponse, err := s.apiClient().ListSegments(personaID, file.FileID, workflowID, query)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segmentSearchResponse.Segments...)
+		total := segmentSearchResponse.Meta.Paging.TotalResults
+		if len(segmentSearchResponse.Segments) < segmentsPageSize || (total > 0 && offset+segmentsPageSize >= total) {
+			return segments, nil
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package segments
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/mock"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func segmentsPage(from, to, total int) []byte {
+	segments := make([]string, 0)
+	for i := from; i < to; i++ {
+		segments = append(segments, fmt.Sprintf(`{"segmentId": %d, "stringKey": "/key-%d", "segment": "source", "target": "target"}`, i, i))
+	}
+	return []byte(fmt.Sprintf(`{"meta": {"paging": {"totalResults": %d}}, "segments": [%s]}`, total, strings.Join(segments, ",")))
+}
+
+func TestSegmentService_ListSegments(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	client := mock.NewMockQordobaClient(controller)
+	workspace := mock.NewMockWorkspaceService(controller)
+	workspace.EXPECT().LoadWorkspace().Return(&types.WorkspaceData{
+		Workflow: []types.Workflow{{ID: 7, Name: "Translation"}},
+	}, nil)
+	pageURL := "https://app.qordoba.com/v3/organizations/0/workspaces/0/personas/3/files/5/workflow/7/segments?limit=100&offset=%d"
+	client.EXPECT().GetFromServer(fmt.Sprintf(pageURL, 0)).Return(segmentsPage(0, 100, 150), nil)
+	client.EXPECT().GetFromServer(fmt.Sprintf(pageURL, 100)).Return(segmentsPage(100, 150, 150), nil)
+	service := &SegmentService{
+		Config:           &types.Config{},
+		QordobaClient:    client,
+		WorkspaceService: workspace,
+	}
+	segments, err := service.ListSegments(&types.File{FileID: 5}, 3)
+	assert.Nil(t, err)
+	assert.Len(t, segments, 150)
+	assert.Equal(t, "/key-149", segments[149].StringKey)
+	assert.Equal(t, "target", segments[149].Target)
+	assert.Equal(t, "Translation", segments[149].Workflow)
+}
+
+func TestSegmentService_ListSegmentsSkipsFailedWorkflow(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	client := mock.NewMockQordobaClient(controller)
+	workspace := mock.NewMockWorkspaceService(controller)
+	workspace.EXPECT().LoadWorkspace().Times(2).Return(&types.WorkspaceData{
+		Workflow: []types.Workflow{{ID: 7, Name: "Translation"}, {ID: 8, Name: "Review"}},
+	}, nil)
+	pageURL := "https://app.qordoba.com/v3/organizations/0/workspaces/0/personas/3/files/5/workflow/%d/segments?limit=100&offset=0"
+	client.EXPECT().GetFromServer(fmt.Sprintf(pageURL, 7)).Return(nil, errors.New("internal server error"))
+	client.EXPECT().GetFromServer(fmt.Sprintf(pageURL, 8)).Return(segmentsPage(0, 2, 2), nil)
+	service := &SegmentService{
+		Config:           &types.Config{},
+		QordobaClient:    client,
+		WorkspaceService: workspace,
+		Logger:           log.New(log.InfoLevel, log.TextFormat, ioutil.Discard),
+	}
+	segments, err := service.ListSegments(&types.File{FileID: 5}, 3)
+	assert.Nil(t, err)
+	assert.Len(t, segments, 2)
+	assert.Equal(t, "Review", segments[0].Workflow)
+
+	client.EXPECT().GetFromServer(gomock.Any()).Times(2).Return(nil, errors.New("internal server error"))
+	_, err = service.ListSegments(&types.File{FileID: 5}, 3)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,63 @@
+package segments
+
+import (
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"regexp"
+)
+
+// SearchSegments searches segments in every file of provided personas. Query is a text searched by server,
+// or a regular expression matched against segment keys when isKeyPattern is set. Files and workflow steps failed
+// to load are logged and skipped
+func (s *SegmentService) SearchSegments(query string, isKeyPattern bool, personas []types.Person) ([]*types.SegmentMatch, error) {
+	search := query
+	var keyPattern *regexp.Regexp
+	if isKeyPattern {
+		pattern, err := regexp.Compile(query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key pattern: %v", err)
+		}
+		search, keyPattern = "", pattern
+	}
+	workspaceData, err := s.WorkspaceService.LoadWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]*types.SegmentMatch, 0)
+	for _, persona := range personas {
+		files, err := s.FileService.WorkspaceFiles(persona.ID, false)
+		if err != nil {
+			s.logger().Errorf("error occurred on files load for %s: %v", persona.Code, err)
+			continue
+		}
+		for i := range files.Files {
+			file := &files.Files[i]
+			if file.Deleted {
+				continue
+			}
+			s.logger().Debugf("search in %s %s for %s", file.Filename, file.Version, persona.Code)
+			for _, workflow := range workspaceData.Workflow {
+				segments, err := s.listWorkflowSegments(file, persona.ID, workflow.ID, search)
+				if err != nil {
+					s.logger().Errorf("error occurred on search in %s %s for %s: %v", file.Filename, file.Version, persona.Code, err)
+					continue
+				}
+				for _, segment := range segments {
+					if keyPattern != nil && !keyPattern.MatchString(segment.StringKey) {
+						continue
+					}
+					matches = append(matches, &types.SegmentMatch{
+						File:     file.Filename,
+						Version:  file.Version,
+						Persona:  persona.Code,
+						Workflow: workflow.Name,
+						Key:      segment.StringKey,
+						Source:   segment.Segment,
+						Target:   segment.Target,
+					})
+				}
+			}
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,98 @@
+package segments
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/qordobacode/cli-v2/pkg/general/log"
+	"github.com/qordobacode/cli-v2/pkg/mock"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+)
+
+func searchService(t *testing.T) (*SegmentService, *mock.MockQordobaClient) {
+	controller := gomock.NewController(t)
+	client := mock.NewMockQordobaClient(controller)
+	workspace := mock.NewMockWorkspaceService(controller)
+	files := mock.NewMockFileService(controller)
+	workspace.EXPECT().LoadWorkspace().Return(&types.WorkspaceData{
+		Workflow: []types.Workflow{{ID: 7, Name: "Translation"}},
+	}, nil)
+	files.EXPECT().WorkspaceFiles(3, false).Return(&types.FileSearchResponse{Files: []types.File{
+		{FileID: 5, Filename: "en.json", Version: "v1"},
+		{FileID: 6, Filename: "old.json", Deleted: true},
+	}}, nil)
+	return &SegmentService{
+		Config:           &types.Config{},
+		QordobaClient:    client,
+		WorkspaceService: workspace,
+		FileService:      files,
+	}, client
+}
+
+func TestSegmentService_SearchSegments(t *testing.T) {
+	service, client := searchService(t)
+	pageURL := "https://app.qordoba.com/v3/organizations/0/workspaces/0/personas/3/files/5/workflow/7/segments?limit=100&offset=%d&search=save+file"
+	client.EXPECT().GetFromServer(fmt.Sprintf(pageURL, 0)).Return(segmentsPage(0, 2, 2), nil)
+	matches, err := service.SearchSegments("save file", false, []types.Person{{ID: 3, Code: "fr-fr"}})
+	assert.Nil(t, err)
+	assert.Len(t, matches, 2)
+	assert.Equal(t, &types.SegmentMatch{
+		File:     "en.json",
+		Version:  "v1",
+		Persona:  "fr-fr",
+		Workflow: "Translation",
+		Key:      "/key-1",
+		Source:   "source",
+		Target:   "target",
+	}, matches[1])
+}
+
+func TestSegmentService_SearchSegmentsByKey(t *testing.T) {
+	service, client := searchService(t)
+	pageURL := "https://app.qordoba.com/v3/organizations/0/workspaces/0/personas/3/files/5/workflow/7/segments?limit=100&offset=%d"
+	client.EXPECT().GetFromServer(fmt.Sprintf(pageURL, 0)).Return(segmentsPage(0, 100, 120), nil)
+	client.EXPECT().GetFromServer(fmt.Sprintf(pageURL, 100)).Return(segmentsPage(100, 120, 120), nil)
+	matches, err := service.SearchSegments(`^/key-11\d$`, true, []types.Person{{ID: 3, Code: "fr-fr"}})
+	assert.Nil(t, err)
+	assert.Len(t, matches, 10)
+	assert.Equal(t, "/key-110", matches[0].Key)
+}
+
+func TestSegmentService_SearchSegmentsSkipsFailedFile(t *testing.T) {
+	controller := gomock.NewController(t)
+	client := mock.NewMockQordobaClient(controller)
+	workspace := mock.NewMockWorkspaceService(controller)
+	files := mock.NewMockFileService(controller)
+	workspace.EXPECT().LoadWorkspace().Return(&types.WorkspaceData{
+		Workflow: []types.Workflow{{ID: 7, Name: "Translation"}},
+	}, nil)
+	files.EXPECT().WorkspaceFiles(3, false).Return(&types.FileSearchResponse{Files: []types.File{
+		{FileID: 5, Filename: "en.json"},
+		{FileID: 6, Filename: "app.json"},
+	}}, nil)
+	files.EXPECT().WorkspaceFiles(4, false).Return(nil, errors.New("internal server error"))
+	pageURL := "https://app.qordoba.com/v3/organizations/0/workspaces/0/personas/3/files/%d/workflow/7/segments?limit=100&offset=0&search=save"
+	client.EXPECT().GetFromServer(fmt.Sprintf(pageURL, 5)).Return(nil, errors.New("internal server error"))
+	client.EXPECT().GetFromServer(fmt.Sprintf(pageURL, 6)).Return(segmentsPage(0, 1, 1), nil)
+	service := &SegmentService{
+		Config:           &types.Config{},
+		QordobaClient:    client,
+		WorkspaceService: workspace,
+		FileService:      files,
+		Logger:           log.New(log.InfoLevel, log.TextFormat, ioutil.Discard),
+	}
+
+	matches, err := service.SearchSegments("save", false, []types.Person{{ID: 3, Code: "fr-fr"}, {ID: 4, Code: "de-de"}})
+	assert.Nil(t, err)
+	assert.Len(t, matches, 1)
+	assert.Equal(t, "app.json", matches[0].File)
+}
+
+func TestSegmentService_SearchSegmentsInvalidPattern(t *testing.T) {
+	matches, err := (&SegmentService{}).SearchSegments("key-(", true, nil)
+	assert.Nil(t, matches)
+	assert.NotNil(t, err)
+}
@@ -1,22 +1,18 @@
 package segments
 
 import (
+	"errors"
 	"fmt"
 	"github.com/qordobacode/cli-v2/pkg"
+	"github.com/qordobacode/cli-v2/pkg/api"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/qordobacode/cli-v2/pkg/types"
-	"io/ioutil"
 	"net/http"
 	"os"
 	"strings"
 )
 
-var (
-	keyAddTemplate     = "%s/v3/organizations/%d/workspaces/%d/files/%d/segments/keyAdd"
-	getSegmentTemplate = "%s/v3/organizations/%d/workspaces/%d/personas/%v/files/%d/workflow/%d/segments?search=%s"
-	keyUpdateTemplate  = "%s/v3/organizations/%d/workspaces/%d/personas/%v/files/%d/segments/%v/sourceUpdate"
-	keyDeleteTemplate  = "%s/v3/organizations/%d/workspaces/%d/files/%d/segments/%v/keyDelete"
-)
+const serverKeySeparator = "/"
 
 // SegmentService struct is an implementation of pkg.SegmentService
 type SegmentService struct {
@@ -24,6 +20,15 @@ type SegmentService struct {
 	FileService      pkg.FileService
 	QordobaClient    pkg.QordobaClient
 	WorkspaceService pkg.WorkspaceService
+	Logger           log.Logger
+}
+
+func (s *SegmentService) logger() log.Logger {
+	return log.OrDefault(s.Logger)
+}
+
+func (s *SegmentService) apiClient() *api.Client {
+	return &api.Client{Config: s.Config, QordobaClient: s.QordobaClient}
 }
 
 // AddKey function add new key into file
@@ -33,73 +38,106 @@ func (s *SegmentService) AddKey(fileName, version string, keyAddRequest *types.K
 	if file == nil {
 		return
 	}
-	base := s.Config.GetAPIBase()
-	addKeyRequestURL := fmt.Sprintf(keyAddTemplate, base, s.Config.Qordoba.OrganizationID, s.Config.Qordoba.WorkspaceID, file.FileID)
-	log.Debugf("call %v to add key", addKeyRequestURL)
-	resp, err := s.QordobaClient.PostToServer(addKeyRequestURL, keyAddRequest)
-	if err != nil {
-		log.Errorf("error occurred on post key-pair: %v", err)
+	if err := s.addFileKey(file, keyAddRequest); err != nil {
+		s.logger().Errorf("Problem to add key '%s'. %v", keyAddRequest.Key, err)
 		return
 	}
-	handleAddKeyResponse(resp, keyAddRequest, version, fileName)
+	if version == "" {
+		s.logger().Infof("Key '%s' was added to file '%s'.", keyAddRequest.Key, fileName)
+	} else {
+		s.logger().Infof("Key '%s' was added to file '%s' (%v).", keyAddRequest.Key, fileName, version)
+	}
 }
 
-func handleAddKeyResponse(resp *http.Response, keyAddRequest *types.KeyAddRequest, version, fileName string) {
-	body, _ := ioutil.ReadAll(resp.Body)
-	if resp.StatusCode/100 != 2 {
-		if resp.StatusCode == http.StatusUnauthorized {
-			log.Errorf("User is not authorised for this request. Check `access_token` in configuration.")
-			os.Exit(1)
-		} else if resp.StatusCode == http.StatusNotAcceptable {
-			log.Errorf("Problem to add key '%s'. Key already exist", keyAddRequest.Key)
-		} else {
-			log.Errorf("Problem to add key '%s'. Status: %v\nResponse : %v", keyAddRequest.Key, resp.Status, string(body))
-		}
-	} else {
-		if version == "" {
-			log.Infof("Key '%s' was added to file '%s'.", keyAddRequest.Key, fileName)
-		} else {
-			log.Infof("Key '%s' was added to file '%s' (%v).", keyAddRequest.Key, fileName, version)
-		}
+func (s *SegmentService) addFileKey(file *types.File, keyAddRequest *types.KeyAddRequest) error {
+	err := s.apiClient().KeyAdd(file.FileID, keyAddRequest)
+	if api.StatusCode(err) == http.StatusNotAcceptable {
+		return errors.New("Key already exist")
 	}
+	return err
 }
 
-// UpdateKey function update key
-func (s *SegmentService) UpdateKey(fileName, version string, keyAddRequest *types.KeyAddRequest) {
+// UpdateKey function update key. Source text is updated for all target personas, translations only for
+// personas listed in options
+func (s *SegmentService) UpdateKey(fileName, version string, keyAddRequest *types.KeyAddRequest, options *types.ValueUpdateOptions) {
 	keyAddRequest.Key = s.handleSegmentKey(keyAddRequest.Key)
 	segment, file := s.FindSegment(fileName, version, keyAddRequest.Key)
-	if segment != nil {
-		base := s.Config.GetAPIBase()
-		var resp *http.Response
-		var err error
-		for _, p := range segment.Personas {
-			updateKeyRequestURL := fmt.Sprintf(keyUpdateTemplate, base, s.Config.Qordoba.OrganizationID, s.Config.Qordoba.WorkspaceID, p.ID, file.FileID, segment.SegmentID)
-			valueUpdateRequest := &types.ValueKeyUpdateRequest{
-				Segment:         keyAddRequest.Source,
-				MoveToFirstStep: false,
-			}
-			resp, err = s.QordobaClient.PutToServer(updateKeyRequestURL, valueUpdateRequest)
-			handleUpdateKeyResult(resp, err, p.Code)
+	if segment == nil {
+		return
+	}
+	personas, isTargetUpdate, err := updatedPersonas(segment, options)
+	if err != nil {
+		s.logger().Errorf("Error on update key: %v", err)
+		return
+	}
+	for _, p := range personas {
+		logger := s.logger().WithFields(log.Fields{"file": fileName, "persona": p.Code})
+		err := s.updatePersonaSegment(isTargetUpdate, file, segment, p, keyAddRequest.Source, options)
+		if err != nil {
+			logger.Errorf("Error on update key: %v", err)
+		} else {
+			logger.Infof("Segment was successfully updated for code %v", p.Code)
 		}
 	}
 }
 
-func handleUpdateKeyResult(resp *http.Response, err error, code string) {
+// updatedPersonas returns personas to update and whether translations (not source) of personas are updated
+func updatedPersonas(segment *types.Segment, options *types.ValueUpdateOptions) ([]types.Person, bool, error) {
+	if options == nil || len(options.Audiences) == 0 {
+		return segment.Personas, false, nil
+	}
+	personas, err := findPersonas(segment.Personas, options.Audiences)
+	return personas, true, err
+}
+
+// findPersonas returns target personas by their codes
+func findPersonas(personas []types.Person, codes []string) ([]types.Person, error) {
+	personaByCode := make(map[string]types.Person, len(personas))
+	availableCodes := make([]string, 0, len(personas))
+	for _, persona := range personas {
+		personaByCode[persona.Code] = persona
+		availableCodes = append(availableCodes, "`"+persona.Code+"`")
+	}
+	found := make([]types.Person, 0, len(codes))
+	for _, code := range codes {
+		persona, ok := personaByCode[code]
+		if !ok {
+			return nil, fmt.Errorf("`%s` does not match one of available target personas: %s", code, strings.Join(availableCodes, ", "))
+		}
+		found = append(found, persona)
+	}
+	return found, nil
+}
+
+func (s *SegmentService) updatePersonaSegment(isTargetUpdate bool, file *types.File, segment *types.Segment, persona types.Person, value string, options *types.ValueUpdateOptions) error {
+	valueUpdateRequest := &types.ValueKeyUpdateRequest{
+		Segment:         value,
+		MoveToFirstStep: options != nil && options.MoveToFirstStep,
+	}
+	if isTargetUpdate {
+		return s.apiClient().TargetUpdate(persona.ID, file.FileID, segment.SegmentID, valueUpdateRequest)
+	}
+	return s.apiClient().SourceUpdate(persona.ID, file.FileID, segment.SegmentID, valueUpdateRequest)
+}
+
+// FindTranslations returns segment by key and its translations into target personas by persona code
+func (s *SegmentService) FindTranslations(fileName, fileVersion, key string, audiences []string) (*types.Segment, map[string]string) {
+	segment, file := s.FindSegment(fileName, fileVersion, key)
+	if segment == nil {
+		return nil, nil
+	}
+	personas, err := findPersonas(segment.Personas, audiences)
 	if err != nil {
-		log.Errorf("error occurred on update key attempt: %v", err)
-		return
+		s.logger().Errorf("%v", err)
+		return nil, nil
 	}
-	body, _ := ioutil.ReadAll(resp.Body)
-	if resp.StatusCode/100 != 2 {
-		if resp.StatusCode == http.StatusUnauthorized {
-			log.Errorf("User is not authorised for this request. Check `access_token` in configuration.")
-			os.Exit(1)
-		} else {
-			log.Errorf("Error on update key: %v. Response: %v", resp.Status, string(body))
+	translations := make(map[string]string, len(personas))
+	for _, persona := range personas {
+		if personaSegment := s.findFileSegment(segment.StringKey, persona.ID, file); personaSegment != nil {
+			translations[persona.Code] = personaSegment.Target
 		}
-	} else {
-		log.Infof("Segment was successfully updated for code %v", code)
 	}
+	return segment, translations
 }
 
 // DeleteKey deletes segment from file by key
@@ -107,63 +145,79 @@ func (s *SegmentService) DeleteKey(fileName, version, segmentKey string) {
 	segmentKey = s.handleSegmentKey(segmentKey)
 	segment, file := s.FindSegment(fileName, version, segmentKey)
 	if segment != nil {
-		base := s.Config.GetAPIBase()
-		updateKeyRequestURL := fmt.Sprintf(keyDeleteTemplate, base, s.Config.Qordoba.OrganizationID, s.Config.Qordoba.WorkspaceID, file.FileID, segment.SegmentID)
-		_, err := s.QordobaClient.DeleteFromServer(updateKeyRequestURL)
-		if err == nil {
+		if s.deleteFileSegment(file, segment) == nil {
 			if version != "" {
-				log.Infof("Segment %v was successfully deleted from %s - %s", segmentKey, fileName, version)
+				s.logger().Infof("Segment %v was successfully deleted from %s - %s", segmentKey, fileName, version)
 			} else {
-				log.Infof("Segment %v was successfully deleted from %s", segmentKey, fileName)
+				s.logger().Infof("Segment %v was successfully deleted from %s", segmentKey, fileName)
 			}
 		}
 	}
 }
 
+func (s *SegmentService) deleteFileSegment(file *types.File, segment *types.Segment) error {
+	return s.apiClient().KeyDelete(file.FileID, segment.SegmentID)
+}
+
 func (s *SegmentService) handleSegmentKey(segmentKey string) string {
-	splittedKeys := strings.Split(segmentKey, "/")
-	if len(splittedKeys) == 1 {
-		log.Info(`Please add "/" to the start of the key`)
+	key, err := s.normalizeSegmentKey(segmentKey)
+	if err != nil {
+		s.logger().Errorf("%v", err)
 		os.Exit(1)
 	}
-	key := splittedKeys[len(splittedKeys)-1]
-	return "/" + key
+	return key
+}
+
+// normalizeSegmentKey converts key into server's `StringKey` form: full path of key levels separated by `/`
+// with leading `/`. Levels of key are split by configured `segments.key_separator` (`/` by default)
+func (s *SegmentService) normalizeSegmentKey(segmentKey string) (string, error) {
+	separator := serverKeySeparator
+	if s.Config != nil && s.Config.Segments.KeySeparator != "" {
+		separator = s.Config.Segments.KeySeparator
+	}
+	levels := make([]string, 0)
+	for _, level := range strings.Split(strings.TrimPrefix(segmentKey, serverKeySeparator), separator) {
+		if level != "" {
+			levels = append(levels, level)
+		}
+	}
+	if len(levels) == 0 {
+		return "", fmt.Errorf("key '%s' is empty", segmentKey)
+	}
+	return serverKeySeparator + strings.Join(levels, serverKeySeparator), nil
 }
 
 // FindSegment returns segment and file where it is placed by file name/version and segment key
 func (s *SegmentService) FindSegment(fileName, fileVersion, key string) (*types.Segment, *types.File) {
-	base := s.Config.GetAPIBase()
+	key, err := s.normalizeSegmentKey(key)
+	if err != nil {
+		s.logger().Errorf("%v", err)
+		return nil, nil
+	}
 	file, personaID := s.FileService.FindFile(fileName, fileVersion, false)
 	if file == nil {
 		return nil, nil
 	}
-	segment := s.findFileSegment(base, key, personaID, file)
+	segment := s.findFileSegment(key, personaID, file)
 	if segment == nil {
 		if fileVersion != "" {
-			log.Errorf("Segment %s in %s - %s was not found", key, fileName, fileVersion)
+			s.logger().Errorf("Segment %s in %s - %s was not found", key, fileName, fileVersion)
 		} else {
-			log.Errorf("Segment %s in %s was not found", key, fileName)
+			s.logger().Errorf("Segment %s in %s was not found", key, fileName)
 		}
 	}
 	return segment, file
 }
 
-func (s *SegmentService) findFileSegment(base, segmentName string, personaID int, file *types.File) *types.Segment {
+func (s *SegmentService) findFileSegment(segmentName string, personaID int, file *types.File) *types.Segment {
 	workspaceData, err := s.WorkspaceService.LoadWorkspace()
 	if err != nil {
 		return nil
 	}
 	for _, workflow := range workspaceData.Workflow {
-		getSegmentRequest := fmt.Sprintf(getSegmentTemplate, base, s.Config.Qordoba.OrganizationID, s.Config.Qordoba.WorkspaceID, personaID, file.FileID, workflow.ID, segmentName)
-		resp, err := s.QordobaClient.GetFromServer(getSegmentRequest)
-		if err != nil {
-			log.Debugf("error occurred: %v", err)
-			continue
-		}
-		var segmentSearchResponse types.SegmentSearchResponse
-		err = segmentSearchResponse.UnmarshalJSON(resp)
+		segmentSearchResponse, err := s.apiClient().ListSegments(personaID, file.FileID, workflow.ID, api.SegmentQuery{Search: segmentName})
 		if err != nil {
-			log.Errorf("error occurred on server segmentSearchResponse unmarshalling: %v", err)
+			s.logger().Debugf("error occurred: %v", err)
 			continue
 		}
 		for _, segment := range segmentSearchResponse.Segments {
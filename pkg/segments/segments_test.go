@@ -119,7 +119,7 @@ func TestSegmentService_UpdateKey(t *testing.T) {
 	}
 	qordobaClient.EXPECT().PutToServer(gomock.Any(), gomock.Any()).Times(5).
 		Return(response, nil)
-	service.UpdateKey("config.yaml", "v1", keyAddRequest)
+	service.UpdateKey("config.yaml", "v1", keyAddRequest, nil)
 }
 
 func TestSegmentService_UpdateKeyErrorOnUpdate(t *testing.T) {
@@ -136,7 +136,48 @@ func TestSegmentService_UpdateKeyErrorOnUpdate(t *testing.T) {
 	}
 	qordobaClient.EXPECT().PutToServer(gomock.Any(), gomock.Any()).Times(5).
 		Return(response, nil)
-	service.UpdateKey("config.yaml", "v1", keyAddRequest)
+	service.UpdateKey("config.yaml", "v1", keyAddRequest, nil)
+}
+
+func TestSegmentService_UpdateKeyTarget(t *testing.T) {
+	service := startSegmentService(t)
+	keyAddRequest := &types.KeyAddRequest{
+		Key:    "/some-key",
+		Source: "texte",
+	}
+	qordobaClient.EXPECT().PutToServer("https://app.qordoba.com/v3/organizations/0/workspaces/0/personas/110/files/0/segments/0/targetUpdate",
+		&types.ValueKeyUpdateRequest{Segment: "texte", MoveToFirstStep: true}).
+		Return(&http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(""))}, nil)
+	service.UpdateKey("config.yaml", "v1", keyAddRequest, &types.ValueUpdateOptions{
+		Audiences:       []string{"fr-fr"},
+		MoveToFirstStep: true,
+	})
+}
+
+func TestSegmentService_UpdateKeyUnknownTarget(t *testing.T) {
+	service := startSegmentService(t)
+	keyAddRequest := &types.KeyAddRequest{
+		Key:    "/some-key",
+		Source: "texte",
+	}
+	service.UpdateKey("config.yaml", "v1", keyAddRequest, &types.ValueUpdateOptions{Audiences: []string{"de-de"}})
+}
+
+func TestSegmentService_FindTranslations(t *testing.T) {
+	service := startSegmentService(t)
+	var workspaceData types.WorkspaceData
+	assert.Nil(t, json.Unmarshal([]byte(workspaceJSON), &workspaceData))
+	workspaceService.EXPECT().LoadWorkspace().AnyTimes().Return(&workspaceData, nil)
+	segment, translations := service.FindTranslations("config.yaml", "v1", "/some-key", []string{"fr-fr", "pl-pl"})
+	assert.NotNil(t, segment)
+	assert.Equal(t, map[string]string{"fr-fr": "string", "pl-pl": "string"}, translations)
+}
+
+func TestSegmentService_FindTranslationsUnknownTarget(t *testing.T) {
+	service := startSegmentService(t)
+	segment, translations := service.FindTranslations("config.yaml", "v1", "/some-key", []string{"de-de"})
+	assert.Nil(t, segment)
+	assert.Nil(t, translations)
 }
 
 func TestSegmentService_DeleteKey(t *testing.T) {
@@ -158,3 +199,28 @@ func Test_Test(t *testing.T) {
 	key := service.handleSegmentKey("/test")
 	assert.Equal(t, key, "/test")
 }
+
+func TestSegmentService_normalizeSegmentKey(t *testing.T) {
+	service := startSegmentService(t)
+	tests := []struct {
+		key       string
+		separator string
+		want      string
+	}{
+		{"test", "", "/test"},
+		{"/menu/file/open", "", "/menu/file/open"},
+		{"menu/file/open/", "", "/menu/file/open"},
+		{"menu.file.open", "", "/menu.file.open"},
+		{"menu.file.open", ".", "/menu/file/open"},
+		{"/menu.file", ".", "/menu/file"},
+	}
+	for _, tt := range tests {
+		service.Config.Segments.KeySeparator = tt.separator
+		key, err := service.normalizeSegmentKey(tt.key)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.want, key)
+	}
+	service.Config.Segments.KeySeparator = ""
+	_, err := service.normalizeSegmentKey("/")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,106 @@
+package segments
+
+import (
+	"fmt"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"strings"
+)
+
+// FindWorkflowStep returns workflow step by its name (case insensitive). Empty name means the first step
+func FindWorkflowStep(workflows []types.Workflow, name string) (*types.Workflow, error) {
+	var found *types.Workflow
+	names := make([]string, 0, len(workflows))
+	for i := range workflows {
+		workflow := &workflows[i]
+		names = append(names, "`"+workflow.Name+"`")
+		if name == "" && (found == nil || workflow.Order < found.Order) {
+			found = workflow
+		}
+		if name != "" && strings.EqualFold(workflow.Name, name) {
+			return workflow, nil
+		}
+	}
+	if found != nil {
+		return found, nil
+	}
+	if name == "" {
+		return nil, fmt.Errorf("workspace has no workflow steps")
+	}
+	return nil, fmt.Errorf("`%s` does not match one of workflow steps: %s", name, strings.Join(names, ", "))
+}
+
+// MoveSegments moves segments of file with listed keys (all segments of file when keys are empty) to workflow step
+// by its name (the first step when name is empty). Audience is a target persona code, default is persona where file was found.
+// Returns result for every key
+func (s *SegmentService) MoveSegments(fileName, version, audience string, keys []string, stepName string) ([]*types.KeyResult, error) {
+	workspaceData, err := s.WorkspaceService.LoadWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	step, err := FindWorkflowStep(workspaceData.Workflow, stepName)
+	if err != nil {
+		return nil, err
+	}
+	file, personaID := s.FileService.FindFile(fileName, version, false)
+	if file == nil {
+		return nil, errFileNotFound
+	}
+	if audience != "" {
+		personas, err := findPersonas(workspaceData.Workspace.TargetPersonas, []string{audience})
+		if err != nil {
+			return nil, err
+		}
+		personaID = personas[0].ID
+	}
+	segmentList, err := s.ListSegments(file, personaID)
+	if err != nil {
+		return nil, err
+	}
+	segmentIDs, results, err := s.selectSegments(segmentList, keys)
+	if err != nil {
+		return nil, err
+	}
+	if len(segmentIDs) == 0 {
+		return results, nil
+	}
+	s.logger().Debugf("move %d segments of file %d to %s", len(segmentIDs), file.FileID, step.Name)
+	err = s.apiClient().WorkflowMove(personaID, file.FileID, &types.WorkflowMoveRequest{
+		SegmentIDs: segmentIDs,
+		WorkflowID: step.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// selectSegments returns IDs of segments with provided keys or of all segments if keys are empty
+func (s *SegmentService) selectSegments(segmentList []types.Segment, keys []string) ([]int, []*types.KeyResult, error) {
+	segmentIDs := make([]int, 0)
+	results := make([]*types.KeyResult, 0)
+	if len(keys) == 0 {
+		for _, segment := range segmentList {
+			segmentIDs = append(segmentIDs, segment.SegmentID)
+			results = append(results, keyResult(segment.StringKey, nil))
+		}
+		return segmentIDs, results, nil
+	}
+	segments := make(map[string]*types.Segment, len(segmentList))
+	for i := range segmentList {
+		segments[segmentList[i].StringKey] = &segmentList[i]
+	}
+	for _, key := range keys {
+		normalizedKey, err := s.normalizeSegmentKey(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		segment, ok := segments[normalizedKey]
+		if !ok {
+			results = append(results, keyResult(key, errSegmentNotFound))
+			continue
+		}
+		segmentIDs = append(segmentIDs, segment.SegmentID)
+		results = append(results, keyResult(key, nil))
+	}
+	return segmentIDs, results, nil
+}
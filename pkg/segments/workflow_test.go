@@ -0,0 +1,61 @@
+package segments
+
+import (
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/qordobacode/cli-v2/pkg/mock"
+	"github.com/qordobacode/cli-v2/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var workflowSteps = []types.Workflow{
+	{ID: 8, Name: "Review", Order: 1},
+	{ID: 7, Name: "Translation", Order: 0},
+}
+
+func TestFindWorkflowStep(t *testing.T) {
+	step, err := FindWorkflowStep(workflowSteps, "")
+	assert.Nil(t, err)
+	assert.Equal(t, 7, step.ID)
+
+	step, err = FindWorkflowStep(workflowSteps, "review")
+	assert.Nil(t, err)
+	assert.Equal(t, 8, step.ID)
+
+	_, err = FindWorkflowStep(workflowSteps, "Publish")
+	assert.EqualError(t, err, "`Publish` does not match one of workflow steps: `Review`, `Translation`")
+
+	_, err = FindWorkflowStep(nil, "")
+	assert.NotNil(t, err)
+}
+
+func TestSegmentService_MoveSegments(t *testing.T) {
+	controller := gomock.NewController(t)
+	client := mock.NewMockQordobaClient(controller)
+	workspace := mock.NewMockWorkspaceService(controller)
+	files := mock.NewMockFileService(controller)
+	workspace.EXPECT().LoadWorkspace().Times(2).Return(&types.WorkspaceData{
+		Workspace: types.Workspace{TargetPersonas: []types.Person{{ID: 3, Code: "fr-fr"}, {ID: 4, Code: "de-de"}}},
+		Workflow:  workflowSteps,
+	}, nil)
+	files.EXPECT().FindFile("en.json", "v1", false).Return(&types.File{FileID: 5}, 3)
+	pageURL := "https://app.qordoba.com/v3/organizations/0/workspaces/0/personas/4/files/5/workflow/%d/segments?limit=100&offset=0"
+	client.EXPECT().GetFromServer(fmt.Sprintf(pageURL, 8)).Return(segmentsPage(0, 2, 2), nil)
+	client.EXPECT().GetFromServer(fmt.Sprintf(pageURL, 7)).Return(segmentsPage(2, 3, 1), nil)
+	client.EXPECT().PostToServer("https://app.qordoba.com/v3/organizations/0/workspaces/0/personas/4/files/5/segments/workflow/move",
+		&types.WorkflowMoveRequest{SegmentIDs: []int{1, 2}, WorkflowID: 8}).Return(okResponse(), nil)
+	service := &SegmentService{
+		Config:           &types.Config{},
+		QordobaClient:    client,
+		WorkspaceService: workspace,
+		FileService:      files,
+	}
+	results, err := service.MoveSegments("en.json", "v1", "de-de", []string{"/key-1", "key-2", "/absent"}, "Review")
+	assert.Nil(t, err)
+	assert.Equal(t, []*types.KeyResult{
+		{Key: "/key-1"},
+		{Key: "key-2"},
+		{Key: "/absent", Error: "segment was not found"},
+	}, results)
+}
@@ -1,6 +1,9 @@
 package types
 
-import "strings"
+import (
+	"path/filepath"
+	"strings"
+)
 
 const (
 	prodAPIEndpoint = "https://app.qordoba.com/"
@@ -12,6 +15,9 @@ type Config struct {
 	Push      PushConfig      `yaml:"push" mapstructure:"push"`
 	Download  DownloadConfig  `yaml:"download" mapstructure:"download"`
 	Blacklist BlacklistConfig `yaml:"blacklist" mapstructure:"blacklist"`
+	Extract   ExtractConfig   `yaml:"extract,omitempty" mapstructure:"extract"`
+	Segments  SegmentsConfig  `yaml:"segments,omitempty" mapstructure:"segments"`
+	HTTP      HTTPConfig      `yaml:"http,omitempty" mapstructure:"http"`
 	BaseURL   string          `yaml:"base_url" mapstructure:"base_url"`
 }
 
@@ -45,6 +51,48 @@ type BlacklistConfig struct {
 	Sources []string `yaml:"sources" mapstructure:"sources"`
 }
 
+// ExtractConfig is a part of config used to extract keys from application source code
+type ExtractConfig struct {
+	Sources  []string `yaml:"sources,omitempty" mapstructure:"sources"`
+	Patterns []string `yaml:"patterns,omitempty" mapstructure:"patterns"`
+	Resource string   `yaml:"resource,omitempty" mapstructure:"resource"`
+}
+
+// SegmentsConfig is a part of config used by segment commands
+type SegmentsConfig struct {
+	// KeySeparator separates levels of hierarchical keys. Keys are stored on server with `/` separator
+	KeySeparator string `yaml:"key_separator,omitempty" mapstructure:"key_separator"`
+}
+
+// HTTPConfig is a network configuration of REST client
+type HTTPConfig struct {
+	// Proxy is URL of HTTP(S) proxy. HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used if it's empty
+	Proxy string `yaml:"proxy,omitempty" mapstructure:"proxy"`
+	// CABundle is a PEM file with certificates trusted in addition to system ones
+	CABundle   string `yaml:"ca_bundle,omitempty" mapstructure:"ca_bundle"`
+	ClientCert string `yaml:"client_cert,omitempty" mapstructure:"client_cert"`
+	ClientKey  string `yaml:"client_key,omitempty" mapstructure:"client_key"`
+	// InsecureSkipVerify disables verification of server certificate. Allowed only with custom `base_url`
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify,omitempty" mapstructure:"insecure_skip_verify"`
+	// MaxIdleConns limits idle keep-alive connections to the server (default 100)
+	MaxIdleConns int `yaml:"max_idle_conns,omitempty" mapstructure:"max_idle_conns"`
+	// IdleConnTimeout is a number of seconds an idle connection is kept open (default 90)
+	IdleConnTimeout int `yaml:"idle_conn_timeout,omitempty" mapstructure:"idle_conn_timeout"`
+	// DisableKeepAlives opens a new connection for every request
+	DisableKeepAlives bool `yaml:"disable_keep_alives,omitempty" mapstructure:"disable_keep_alives"`
+	// DisableHTTP2 forces HTTP/1.1, e.g. for proxies not supporting HTTP/2
+	DisableHTTP2 bool `yaml:"disable_http2,omitempty" mapstructure:"disable_http2"`
+	// RateLimit is max requests per second of all workers (unlimited if zero, the default, or negative)
+	RateLimit float64 `yaml:"rate_limit,omitempty" mapstructure:"rate_limit"`
+	// MaxConcurrent is max requests sent at the same time (unlimited if zero, the default, or negative)
+	MaxConcurrent int `yaml:"max_concurrent,omitempty" mapstructure:"max_concurrent"`
+}
+
+// IsProduction returns true if API endpoint is production one
+func (c *Config) IsProduction() bool {
+	return c.GetAPIBase() == strings.TrimSuffix(prodAPIEndpoint, "/")
+}
+
 // GetAPIBase get value of API endpoint from config OR prod as a default
 func (c *Config) GetAPIBase() string {
 	base := prodAPIEndpoint
@@ -63,3 +111,11 @@ func (c *Config) Audiences() map[string]bool {
 	}
 	return results
 }
+
+// DownloadPath resolves path of downloaded file relative to the first `push.sources.folders` folder
+func (c *Config) DownloadPath(fileName string) string {
+	if len(c.Push.Sources.Folders) > 0 {
+		return filepath.Join(c.Push.Sources.Folders[0], fileName)
+	}
+	return fileName
+}
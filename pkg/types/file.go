@@ -22,3 +22,20 @@ type File2Download struct {
 	ReplaceIn  string
 	ReplaceMap map[string]string
 }
+
+// file result statuses
+const (
+	FilePushed     = "pushed"
+	FileDownloaded = "downloaded"
+	FileSkipped    = "skipped"
+	FileFailed     = "failed"
+)
+
+// FileResult is a result of push or download of a single file. Error is empty on success
+type FileResult struct {
+	File     string `json:"file"`
+	Version  string `json:"version,omitempty"`
+	Audience string `json:"audience,omitempty"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
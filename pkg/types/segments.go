@@ -13,6 +13,19 @@ type ValueKeyUpdateRequest struct {
 	MoveToFirstStep bool   `json:"moveToFirstStep"`
 }
 
+// ValueUpdateOptions configures value update. Source text is updated when Audiences are empty,
+// otherwise translations of listed target persona codes are updated
+type ValueUpdateOptions struct {
+	Audiences       []string
+	MoveToFirstStep bool
+}
+
+// WorkflowMoveRequest struct for request to move segments to workflow step
+type WorkflowMoveRequest struct {
+	SegmentIDs []int `json:"segmentIds"`
+	WorkflowID int   `json:"workflowId"`
+}
+
 // SegmentSearchResponse struct
 type SegmentSearchResponse struct {
 	Meta     Meta      `json:"meta"`
@@ -27,5 +40,24 @@ type Segment struct {
 	Segment   string   `json:"segment"`
 	SsText    string   `json:"ssText"`
 	StringKey string   `json:"stringKey"`
+	Target    string   `json:"target"`
 	Personas  []Person `json:"-"`
+	Workflow  string   `json:"-"`
+}
+
+// SegmentMatch is a segment found by workspace search
+type SegmentMatch struct {
+	File     string `json:"file"`
+	Version  string `json:"version"`
+	Persona  string `json:"persona"`
+	Workflow string `json:"workflow"`
+	Key      string `json:"key"`
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+}
+
+// KeyResult is a result of a single key operation in bulk request. Error is empty on success
+type KeyResult struct {
+	Key   string `json:"key"`
+	Error string `json:"error,omitempty"`
 }
@@ -226,6 +226,8 @@ func easyjsonCeef645aDecodeGithubComQordobacodeCliV2PkgTypes2(in *jlexer.Lexer,
 			out.SsText = string(in.String())
 		case "stringKey":
 			out.StringKey = string(in.String())
+		case "target":
+			out.Target = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -270,6 +272,11 @@ func easyjsonCeef645aEncodeGithubComQordobacodeCliV2PkgTypes2(out *jwriter.Write
 		out.RawString(prefix)
 		out.String(string(in.StringKey))
 	}
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix)
+		out.String(string(in.Target))
+	}
 	out.RawByte('}')
 }
 
@@ -4,19 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"github.com/qordobacode/cli-v2/pkg"
+	"github.com/qordobacode/cli-v2/pkg/api"
 	"github.com/qordobacode/cli-v2/pkg/general/log"
 	"github.com/qordobacode/cli-v2/pkg/types"
 	"time"
 )
 
 const (
-	getWorkspacesTemplate = "%s/v3/organizations/%d/workspaces?limit=%d&offset=%d"
-	limit                 = 500
-	workspaceFileName     = "workspace.json"
-)
-
-var (
-	workspaceCacheWasUpdated = false
+	limit             = 500
+	workspaceFileName = "workspace.json"
 )
 
 // Service implements pkg.Service
@@ -24,6 +20,18 @@ type Service struct {
 	Config        *types.Config
 	QordobaClient pkg.QordobaClient
 	Local         pkg.Local
+	Logger        log.Logger
+
+	// isCacheUpdated is set when workspaces were loaded from server, so they aren't requested again
+	isCacheUpdated bool
+}
+
+func (w *Service) logger() log.Logger {
+	return log.OrDefault(w.Logger)
+}
+
+func (w *Service) apiClient() *api.Client {
+	return &api.Client{Config: w.Config, QordobaClient: w.QordobaClient}
 }
 
 // LoadWorkspace function retrieves a workspace
@@ -40,7 +48,7 @@ func (w *Service) LoadWorkspace() (*types.WorkspaceData, error) {
 }
 
 func (w *Service) WorkspaceFromServer() (*types.WorkspaceData, error) {
-	if workspaceCacheWasUpdated {
+	if w.isCacheUpdated {
 		return nil, errors.New("workspace has been already updated")
 	}
 	workspaceResponse, err := w.loadServerWorkspaceResponse()
@@ -54,7 +62,7 @@ func (w *Service) WorkspaceFromServer() (*types.WorkspaceData, error) {
 		return nil, err
 	}
 	err = fmt.Errorf("workspace with id=%v was not found", w.Config.Qordoba.WorkspaceID)
-	log.Errorf(err.Error())
+	w.logger().Errorf(err.Error())
 	return nil, err
 }
 
@@ -68,7 +76,7 @@ func (w *Service) cachedWorkspace() (*types.WorkspaceResponse, error) {
 	var workspaceResponse types.WorkspaceResponse
 	err = workspaceResponse.UnmarshalJSON(bodyBytes)
 	if err != nil {
-		log.Errorf("error occurred on cached workspace read: %v", err)
+		w.logger().Errorf("error occurred on cached workspace read: %v", err)
 		return nil, err
 	}
 	return &workspaceResponse, nil
@@ -76,9 +84,8 @@ func (w *Service) cachedWorkspace() (*types.WorkspaceResponse, error) {
 
 // loadServerWorkspaceResponse function retrieve list of all workspaces
 func (w *Service) loadServerWorkspaceResponse() (*types.WorkspaceResponse, error) {
-	log.Infof("start to download organization's workspace structure...")
+	w.logger().Infof("start to download organization's workspace structure...")
 	start := time.Now()
-	base := w.Config.GetAPIBase()
 	result := &types.WorkspaceResponse{
 		Meta: types.Meta{
 			Paging: types.Paging{
@@ -92,9 +99,11 @@ func (w *Service) loadServerWorkspaceResponse() (*types.WorkspaceResponse, error
 	errNum := 0
 	for offset := 0; offset < result.Meta.Paging.TotalResults; offset += limit {
 		// retrieve from server list of workspaces
-		workspaceRequestURL := fmt.Sprintf(getWorkspacesTemplate, base, w.Config.Qordoba.OrganizationID, limit, offset)
-		bodyBytes, err := w.QordobaClient.GetFromServer(workspaceRequestURL)
+		workspaceResponse, err := w.apiClient().ListWorkspaces(limit, offset)
 		if err != nil {
+			if api.StatusCode(err) == 0 {
+				w.logger().Errorf("error occurred on request for workspace: %v", err)
+			}
 			if errNum == 0 {
 				// try to repeat failed request 1 time
 				offset -= limit
@@ -102,24 +111,18 @@ func (w *Service) loadServerWorkspaceResponse() (*types.WorkspaceResponse, error
 			}
 			continue
 		}
-		var workspaceResponse types.WorkspaceResponse
-		err = workspaceResponse.UnmarshalJSON(bodyBytes)
-		if err != nil {
-			log.Errorf("error occurred on request for workspace: %v", err)
-			continue
-		}
 		result.Meta.Paging.TotalResults = workspaceResponse.Meta.Paging.TotalResults
 		result.Meta.Paging.TotalEnabled = workspaceResponse.Meta.Paging.TotalEnabled
 		result.Workspaces = append(result.Workspaces, workspaceResponse.Workspaces...)
 		errNum = 0
 		elapsed := time.Since(start)
-		log.Infof("%v. Downloaded %d/%d organization's workspaces", elapsed, len(result.Workspaces), result.Meta.Paging.TotalResults)
+		w.logger().Infof("%v. Downloaded %d/%d organization's workspaces", elapsed, len(result.Workspaces), result.Meta.Paging.TotalResults)
 	}
 
 	bytes, err := result.MarshalJSON()
 	if err == nil {
 		w.Local.PutInHome(workspaceFileName, bytes)
 	}
-	workspaceCacheWasUpdated = true
+	w.isCacheUpdated = true
 	return result, nil
 }
@@ -34,7 +34,6 @@ func buildWorkspaceTest(t *testing.T) *Service {
 }
 
 func TestService_LoadWorkspace(t *testing.T) {
-	workspaceCacheWasUpdated = false
 	service := buildWorkspaceTest(t)
 	data, err := service.LoadWorkspace()
 	assert.Nil(t, err)
@@ -42,7 +41,6 @@ func TestService_LoadWorkspace(t *testing.T) {
 }
 
 func TestService_LoadWorkspaceLoadCached(t *testing.T) {
-	workspaceCacheWasUpdated = false
 	service := buildWorkspaceTest(t)
 	local.EXPECT().LoadCached(workspaceFileName).Return([]byte(workspace), nil)
 	data, err := service.LoadWorkspace()